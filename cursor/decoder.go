@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"reflect"
+	"strings"
 
 	"github.com/pilagod/gorm-cursor-paginator/v2/internal/util"
 )
@@ -26,6 +27,7 @@ type DecoderField struct {
 }
 
 // Decode decodes cursor into values (without pointer) by referencing field type on model.
+// Values for map model are inferred from JSON, unless field type is given.
 func (d *Decoder) Decode(cursor string, model interface{}) (fields []interface{}, err error) {
 	if err = d.validate(model); err != nil {
 		return
@@ -45,14 +47,31 @@ func (d *Decoder) Decode(cursor string, model interface{}) (fields []interface{}
 		var t reflect.Type
 		if field.Type != nil {
 			t = *field.Type
-		} else {
+		} else if modelType := util.ReflectType(model); modelType.Kind() != reflect.Map {
 			// key is already validated at beginning
-			f, _ := util.ReflectType(model).FieldByName(field.Key)
-			t = f.Type
+			t, _ = util.FieldTypeByPath(modelType, field.Key)
 		}
 
+		var raw json.RawMessage
+		if err := jd.Decode(&raw); err != nil {
+			return nil, ErrInvalidCursor
+		}
+		// map model has no type for its values, they are inferred from JSON
+		if t == nil {
+			v, err := inferValue(raw)
+			if err != nil {
+				return nil, ErrInvalidCursor
+			}
+			fields = append(fields, v)
+			continue
+		}
+		// nested field is null when its path passes through a nil pointer, e.g. a missing association
+		if bytes.Equal(raw, []byte("null")) && t.Kind() != reflect.Ptr && strings.Contains(field.Key, ".") {
+			fields = append(fields, nil)
+			continue
+		}
 		v := reflect.New(t).Interface()
-		if err := jd.Decode(v); err != nil {
+		if err := json.Unmarshal(raw, v); err != nil {
 			return nil, ErrInvalidCursor
 		}
 		fields = append(fields, reflect.ValueOf(v).Elem().Interface())
@@ -62,6 +81,24 @@ func (d *Decoder) Decode(cursor string, model interface{}) (fields []interface{}
 	return
 }
 
+// inferValue decodes JSON value without type, numbers are decoded as int64 when they are
+// integers, or float64 otherwise
+func inferValue(raw json.RawMessage) (interface{}, error) {
+	jd := json.NewDecoder(bytes.NewReader(raw))
+	jd.UseNumber()
+	var v interface{}
+	if err := jd.Decode(&v); err != nil {
+		return nil, err
+	}
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+		return n.Float64()
+	}
+	return v, nil
+}
+
 // DecodeStruct decodes cursor into model, model must be a pointer to struct or it will panic.
 func (d *Decoder) DecodeStruct(cursor string, model interface{}) (err error) {
 	fields, err := d.Decode(cursor, model)
@@ -70,19 +107,28 @@ func (d *Decoder) DecodeStruct(cursor string, model interface{}) (err error) {
 	}
 	elem := reflect.ValueOf(model).Elem()
 	for i, field := range d.fields {
-		elem.FieldByName(field.Key).Set(reflect.ValueOf(fields[i]))
+		if fields[i] == nil {
+			// leave pointers on path of null nested field as nil
+			continue
+		}
+		f, _ := util.SettableFieldByPath(elem, field.Key)
+		f.Set(reflect.ValueOf(fields[i]))
 	}
 	return
 }
 
 func (d *Decoder) validate(model interface{}) error {
 	modelType := util.ReflectType(model)
+	// keys of map model are known only from cursor
+	if modelType.Kind() == reflect.Map && modelType.Key().Kind() == reflect.String {
+		return nil
+	}
 	// model's underlying type must be a struct
 	if modelType.Kind() != reflect.Struct {
 		return ErrInvalidModel
 	}
 	for _, field := range d.fields {
-		if _, ok := modelType.FieldByName(field.Key); !ok {
+		if _, ok := util.FieldTypeByPath(modelType, field.Key); !ok {
 			return ErrInvalidModel
 		}
 	}
@@ -65,3 +65,11 @@ func (s *decoderSuite) TestDecodeStructInvalidCursor() {
 	err := NewDecoder([]DecoderField{{Key: "Value"}}).DecodeStruct("123", struct{ Value string }{})
 	s.Equal(ErrInvalidCursor, err)
 }
+
+func (s *decoderSuite) TestDecodeNestedPathNotMatchedModel() {
+	type model struct {
+		Value struct{ Name string }
+	}
+	_, err := NewDecoder([]DecoderField{{Key: "Value.Unknown"}}).Decode("cursor", model{})
+	s.Equal(ErrInvalidModel, err)
+}
@@ -38,11 +38,13 @@ func (e *Encoder) marshalJSON(model interface{}) ([]byte, error) {
 	rv := util.ReflectValue(model)
 	fields := make([]interface{}, len(e.fields))
 	for i, field := range e.fields {
-		f := rv.FieldByName(field.Key)
-		if f == (reflect.Value{}) {
+		// key may be a path to field of nested struct, e.g. "Order.CreatedAt"
+		f, ok := util.FieldByPath(rv, field.Key)
+		if !ok {
 			return nil, ErrInvalidModel
 		}
-		if e.isNilable(f) && f.IsZero() {
+		// field under nil nested struct is taken as nil
+		if !f.IsValid() || (e.isNilable(f) && f.IsZero()) {
 			fields[i] = nil
 		} else {
 			// fetch values from custom types
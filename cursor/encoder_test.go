@@ -57,3 +57,8 @@ func (s *encoderSuite) TestSliceValue() {
 	_, err := e.Encode(struct{ Slice []string }{Slice: []string{"value"}})
 	s.Nil(err)
 }
+
+func (s *encoderSuite) TestMissingMapKey() {
+	_, err := NewEncoder([]EncoderField{{Key: "id"}}).Encode(map[string]interface{}{"name": "a"})
+	s.Equal(ErrInvalidModel, err)
+}
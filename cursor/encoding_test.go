@@ -164,6 +164,46 @@ func (s *encodingSuite) TestStructPtr() {
 	s.Equal(sv, *(v.(*structValue)))
 }
 
+/* nested */
+
+type nestedModel struct {
+	ID       int
+	Value    nestedValue
+	ValuePtr *nestedValue
+}
+
+type nestedValue struct {
+	Name string
+}
+
+func (s *encodingSuite) TestNestedPath() {
+	fields := []EncoderField{{Key: "Value.Name"}, {Key: "ValuePtr.Name"}, {Key: "ID"}}
+	c, err := NewEncoder(fields).Encode(nestedModel{
+		ID:       1,
+		Value:    nestedValue{Name: "a"},
+		ValuePtr: &nestedValue{Name: "b"},
+	})
+	s.Nil(err)
+
+	decoder := NewDecoder([]DecoderField{{Key: "Value.Name"}, {Key: "ValuePtr.Name"}, {Key: "ID"}})
+	v, err := decoder.Decode(c, nestedModel{})
+	s.Nil(err)
+	s.Equal([]interface{}{"a", "b", 1}, v)
+
+	var model nestedModel
+	s.Nil(decoder.DecodeStruct(c, &model))
+	s.Equal(nestedModel{ID: 1, Value: nestedValue{Name: "a"}, ValuePtr: &nestedValue{Name: "b"}}, model)
+}
+
+func (s *encodingSuite) TestNestedPathThroughNilPtr() {
+	c, err := NewEncoder([]EncoderField{{Key: "ValuePtr.Name"}}).Encode(nestedModel{})
+	s.Nil(err)
+
+	v, err := NewDecoder([]DecoderField{{Key: "ValuePtr.Name"}}).Decode(c, nestedModel{})
+	s.Nil(err)
+	s.Equal([]interface{}{nil}, v)
+}
+
 /* slice */
 
 type sliceModel struct {
@@ -396,3 +436,33 @@ func (s *encodingSuite) TestEncodeDecodeCustomTypes() {
 		})
 	}
 }
+
+/* map */
+
+func (s *encodingSuite) TestMap() {
+	fields := []EncoderField{{Key: "id"}, {Key: "name"}, {Key: "score"}, {Key: "remark"}}
+	c, err := NewEncoder(fields).Encode(map[string]interface{}{
+		"id":     1,
+		"name":   "a",
+		"score":  1.5,
+		"remark": nil,
+	})
+	s.Nil(err)
+
+	v, err := NewDecoder([]DecoderField{{Key: "id"}, {Key: "name"}, {Key: "score"}, {Key: "remark"}}).
+		Decode(c, &[]map[string]interface{}{})
+	s.Nil(err)
+	s.Equal([]interface{}{int64(1), "a", 1.5, nil}, v)
+}
+
+func (s *encodingSuite) TestMapWithType() {
+	t := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	c, err := NewEncoder([]EncoderField{{Key: "created_at"}}).Encode(map[string]interface{}{"created_at": t})
+	s.Nil(err)
+
+	timeType := reflect.TypeOf(time.Time{})
+	v, err := NewDecoder([]DecoderField{{Key: "created_at", Type: &timeType}}).
+		Decode(c, map[string]interface{}{})
+	s.Nil(err)
+	s.Equal([]interface{}{t}, v)
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"reflect"
+	"strings"
 )
 
 // ReflectValue returns reflect value underlying given value, unwrapping pointer
@@ -33,3 +34,76 @@ func ReflectType(v interface{}) reflect.Type {
 	}
 	return rt
 }
+
+// FieldByPath returns field of struct value by dotted path (e.g. "Order.CreatedAt"), walking through
+// nested structs and pointers to struct, or entry of map keyed by string (e.g. "created_at").
+// It returns invalid value with ok when path goes through nil pointer.
+func FieldByPath(v reflect.Value, path string) (field reflect.Value, ok bool) {
+	field = ReflectValue(v)
+	for i, name := range strings.Split(path, ".") {
+		if i > 0 {
+			if (field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface) && field.IsNil() {
+				return reflect.Value{}, true
+			}
+			field = reflect.Indirect(field)
+		}
+		switch {
+		case field.Kind() == reflect.Struct:
+			field = field.FieldByName(name)
+		case field.Kind() == reflect.Map && field.Type().Key().Kind() == reflect.String:
+			field = field.MapIndex(reflect.ValueOf(name).Convert(field.Type().Key()))
+			// entry of map[string]interface{} holds value of any type
+			if field.IsValid() && field.Kind() == reflect.Interface && !field.IsNil() {
+				field = field.Elem()
+			}
+		default:
+			return reflect.Value{}, false
+		}
+		if !field.IsValid() {
+			return reflect.Value{}, false
+		}
+	}
+	return field, true
+}
+
+// SettableFieldByPath returns field of struct value by dotted path as FieldByPath does,
+// allocating nil pointers on the way. v must be addressable.
+func SettableFieldByPath(v reflect.Value, path string) (field reflect.Value, ok bool) {
+	field = ReflectValue(v)
+	for i, name := range strings.Split(path, ".") {
+		if i > 0 && field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			field = field.Elem()
+		}
+		if field.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		if field = field.FieldByName(name); !field.IsValid() {
+			return reflect.Value{}, false
+		}
+	}
+	return field, true
+}
+
+// FieldTypeByPath returns type of struct field by dotted path as FieldByPath does
+func FieldTypeByPath(t reflect.Type, path string) (reflect.Type, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i, name := range strings.Split(path, ".") {
+		if i > 0 && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, false
+		}
+		f, ok := t.FieldByName(name)
+		if !ok {
+			return nil, false
+		}
+		t = f.Type
+	}
+	return t, true
+}
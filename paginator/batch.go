@@ -0,0 +1,122 @@
+package paginator
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BatchHandler handles rows of one batch, which are already loaded into dest given to BatchRunner.Run.
+// tx is a fresh session bound to the batch transaction when transaction is enabled.
+type BatchHandler func(tx *gorm.DB, batch int) error
+
+// NewBatchRunner creates batch runner, opts are applied to the paginator of every batch
+func NewBatchRunner(name string, store CheckpointStore, opts ...Option) *BatchRunner {
+	return &BatchRunner{
+		name:  name,
+		store: store,
+		opts:  opts,
+	}
+}
+
+// BatchRunner walks through all rows of a query in batches, and saves the
+// cursor of last processed row as checkpoint after every batch, so that an
+// interrupted run resumes from the checkpoint on next run.
+type BatchRunner struct {
+	name        string
+	store       CheckpointStore
+	opts        []Option
+	transaction bool
+	throttle    time.Duration
+}
+
+// SetTransaction enables or disables wrapping each batch in its own transaction
+func (r *BatchRunner) SetTransaction(enabled bool) {
+	r.transaction = enabled
+}
+
+// SetThrottle sets the duration to wait between batches
+func (r *BatchRunner) SetThrottle(throttle time.Duration) {
+	r.throttle = throttle
+}
+
+// Run runs handler batch by batch until all rows are processed, dest must be a pointer to slice
+func (r *BatchRunner) Run(db *gorm.DB, dest interface{}, handler BatchHandler) error {
+	if !isSlicePtr(dest) {
+		return ErrInvalidDest
+	}
+	checkpoint, err := r.store.Load(r.name)
+	if err != nil {
+		return err
+	}
+	for batch := 1; ; batch++ {
+		var done bool
+		run := func(tx *gorm.DB, store CheckpointStore) error {
+			opts := r.opts
+			if checkpoint != nil {
+				opts = append(opts[:len(opts):len(opts)], WithAfter(*checkpoint))
+			}
+			p := New(opts...)
+			result, _, err := p.Paginate(tx, dest)
+			if err != nil {
+				return err
+			}
+			if result.Error != nil {
+				return result.Error
+			}
+			elems := reflect.ValueOf(dest).Elem()
+			if elems.Len() == 0 {
+				done = true
+				return nil
+			}
+			if err := handler(tx.Session(&gorm.Session{NewDB: true}), batch); err != nil {
+				return err
+			}
+			// always checkpoint at last row, even on last batch, so that
+			// next run only picks up rows coming after it
+			c, err := p.encodeCursor(elems, true)
+			if err != nil {
+				return err
+			}
+			if err := store.Save(r.name, *c.After); err != nil {
+				return err
+			}
+			checkpoint = c.After
+			return nil
+		}
+		if r.transaction {
+			err = db.Transaction(func(tx *gorm.DB) error {
+				store := r.store
+				if s, ok := store.(txCheckpointStore); ok {
+					store = s.withTx(tx)
+				}
+				return run(tx, store)
+			})
+		} else {
+			err = run(db.Session(&gorm.Session{}), r.store)
+		}
+		if err != nil || done {
+			return err
+		}
+		if err = r.wait(db.Statement.Context); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *BatchRunner) wait(ctx context.Context) error {
+	if r.throttle <= 0 {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(r.throttle):
+		return nil
+	}
+}
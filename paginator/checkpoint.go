@@ -0,0 +1,110 @@
+package paginator
+
+import (
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CheckpointStore persists checkpoints (cursors of last processed rows) for batch runners
+type CheckpointStore interface {
+	// Load loads checkpoint by name, it returns nil when there is no checkpoint yet
+	Load(name string) (*string, error)
+
+	// Save saves checkpoint by name
+	Save(name string, checkpoint string) error
+}
+
+// NewMemoryCheckpointStore creates checkpoint store living in memory
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{
+		checkpoints: make(map[string]string),
+	}
+}
+
+// MemoryCheckpointStore keeps checkpoints in memory, checkpoints are lost when process exits
+type MemoryCheckpointStore struct {
+	mu          sync.RWMutex
+	checkpoints map[string]string
+}
+
+// Load loads checkpoint by name
+func (s *MemoryCheckpointStore) Load(name string) (*string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if checkpoint, ok := s.checkpoints[name]; ok {
+		return &checkpoint, nil
+	}
+	return nil, nil
+}
+
+// Save saves checkpoint by name
+func (s *MemoryCheckpointStore) Save(name string, checkpoint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[name] = checkpoint
+	return nil
+}
+
+// Checkpoint is the model persisted by GormCheckpointStore
+type Checkpoint struct {
+	Name      string `gorm:"primaryKey;type:varchar(255)"`
+	Cursor    string `gorm:"type:text;not null"`
+	UpdatedAt time.Time
+}
+
+// TableName of checkpoint model
+func (Checkpoint) TableName() string {
+	return "paginator_checkpoints"
+}
+
+// NewGormCheckpointStore creates checkpoint store backed by a database table
+func NewGormCheckpointStore(db *gorm.DB) *GormCheckpointStore {
+	return &GormCheckpointStore{
+		db: db.Session(&gorm.Session{NewDB: true}),
+	}
+}
+
+// GormCheckpointStore keeps checkpoints in table "paginator_checkpoints"
+type GormCheckpointStore struct {
+	db *gorm.DB
+}
+
+// Migrate creates or updates the checkpoint table
+func (s *GormCheckpointStore) Migrate() error {
+	return s.db.AutoMigrate(&Checkpoint{})
+}
+
+// Load loads checkpoint by name
+func (s *GormCheckpointStore) Load(name string) (*string, error) {
+	var checkpoints []Checkpoint
+	if err := s.db.Where(&Checkpoint{Name: name}).Limit(1).Find(&checkpoints).Error; err != nil {
+		return nil, err
+	}
+	if len(checkpoints) == 0 {
+		return nil, nil
+	}
+	return &checkpoints[0].Cursor, nil
+}
+
+// Save saves checkpoint by name
+func (s *GormCheckpointStore) Save(name string, checkpoint string) error {
+	return s.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&Checkpoint{
+		Name:   name,
+		Cursor: checkpoint,
+	}).Error
+}
+
+// withTx binds store to transaction, so that checkpoint is committed along with the batch
+func (s *GormCheckpointStore) withTx(tx *gorm.DB) CheckpointStore {
+	return &GormCheckpointStore{
+		db: tx.Session(&gorm.Session{NewDB: true}),
+	}
+}
+
+// txCheckpointStore is implemented by stores able to save checkpoints within transaction
+type txCheckpointStore interface {
+	withTx(tx *gorm.DB) CheckpointStore
+}
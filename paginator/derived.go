@@ -0,0 +1,37 @@
+package paginator
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// wrapDerivedTable wraps query of db as derived table, so that paging clauses and cursor query
+// go on the outer query, and query can be of any form, e.g. db.Raw, UNION, CTE or window function:
+//
+//	SELECT * FROM (<query>) AS t WHERE <cursor query> ORDER BY ... LIMIT 11
+func (p *Paginator) wrapDerivedTable(db *gorm.DB, dest interface{}) *gorm.DB {
+	inner := db.Session(&gorm.Session{})
+	if inner.Statement.SQL.Len() == 0 && inner.Statement.Model == nil && inner.Statement.Table == "" {
+		inner = inner.Model(dest)
+	}
+	stmt := db.Session(&gorm.Session{NewDB: true}).Table("(?) AS ?", inner, clause.Table{Name: p.derivedTable})
+	// rules refer to columns of derived table
+	stmt.Statement.Table = p.derivedTable
+	return stmt
+}
+
+// getTableName returns table which SQL representations of rules are qualified by
+func (p *Paginator) getTableName(db *gorm.DB, dest interface{}) (string, error) {
+	if p.derivedTable != "" {
+		return p.derivedTable, nil
+	}
+	// table-only query has no model, and dest may be a DTO of another table name
+	if db.Statement.Model == nil && (db.Statement.Table != "" || isMapDest(dest)) {
+		return db.Statement.Table, nil
+	}
+	s, err := parseModelSchema(db, dest)
+	if err != nil {
+		return "", err
+	}
+	return s.Table, nil
+}
@@ -0,0 +1,89 @@
+package paginator
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// dialectVersioner is implemented by dialectors knowing version of their database
+type dialectVersioner interface {
+	Version() string
+}
+
+// sqliteVersions caches SQLite versions by *sql.DB, which is shared by sessions of the same database
+var sqliteVersions sync.Map
+
+// supportsTupleCmp tells whether dialect of db supports row value comparison, e.g. (a, b) > (?, ?)
+func supportsTupleCmp(db *gorm.DB) bool {
+	switch db.Dialector.Name() {
+	case "postgres", "mysql":
+		return true
+	case "sqlite":
+		// https://www.sqlite.org/rowvalue.html
+		return versionAtLeast(dialectVersion(db), 3, 15)
+	}
+	return false
+}
+
+// supportsNullsOrder tells whether dialect of db supports NULLS FIRST / NULLS LAST in ORDER BY
+func supportsNullsOrder(db *gorm.DB) bool {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return true
+	case "sqlite":
+		return versionAtLeast(dialectVersion(db), 3, 30)
+	}
+	return false
+}
+
+// supportsReturning tells whether dialect of db supports data-modifying statements with RETURNING in WITH
+func supportsReturning(db *gorm.DB) bool {
+	return db.Dialector.Name() == "postgres"
+}
+
+// dialectVersion returns version of database behind db, or empty string when it is unknown
+func dialectVersion(db *gorm.DB) string {
+	if v, ok := db.Dialector.(dialectVersioner); ok {
+		return v.Version()
+	}
+	if db.Dialector.Name() != "sqlite" || db.DryRun {
+		return ""
+	}
+	// config is copied by every session, while its *sql.DB stays the same. Transactions have
+	// no *sql.DB and are not cached, otherwise the cache would keep every transaction alive.
+	sqlDB, err := db.DB()
+	cacheable := err == nil
+	if cacheable {
+		if version, ok := sqliteVersions.Load(sqlDB); ok {
+			return version.(string)
+		}
+	}
+	var version string
+	if err := db.Session(&gorm.Session{NewDB: true}).Raw("SELECT sqlite_version()").Scan(&version).Error; err != nil {
+		return ""
+	}
+	if cacheable {
+		sqliteVersions.Store(sqlDB, version)
+	}
+	return version
+}
+
+// versionAtLeast tells whether version is at least major.minor, unknown version is never
+func versionAtLeast(version string, major int, minor int) bool {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	vMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	vMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return vMajor > major || (vMajor == major && vMinor >= minor)
+}
@@ -4,9 +4,25 @@ import "errors"
 
 // Errors for paginator
 var (
-	ErrInvalidCursor = errors.New("invalid cursor for paginating")
-	ErrInvalidLimit  = errors.New("limit should be greater than 0")
-	ErrInvalidModel  = errors.New("model fields should match rules or keys specified for paginator")
-	ErrInvalidOrder  = errors.New("order should be ASC or DESC")
-	ErrNoRule        = errors.New("paginator should have at least one rule")
+	ErrInvalidCursor        = errors.New("invalid cursor for paginating")
+	ErrInvalidLimit         = errors.New("limit should be greater than 0")
+	ErrInvalidModel         = errors.New("model fields should match rules or keys specified for paginator")
+	ErrInvalidOrder         = errors.New("order should be ASC or DESC")
+	ErrInvalidNullsOrder    = errors.New("nulls order should be NULLS FIRST or NULLS LAST")
+	ErrInvalidSQLType       = errors.New("sql type should be an allowed type name, see AllowSQLTypes")
+	ErrInvalidSQLVars       = errors.New("sql vars should match placeholders of sql representation one by one")
+	ErrNoRule               = errors.New("paginator should have at least one rule")
+	ErrInvalidTiebreaker    = errors.New("tiebreaker should be APPEND, ERROR or NONE")
+	ErrInvalidTag           = errors.New("paginate tag should be in form of order=asc|desc,nulls=first|last,priority=<int>")
+	ErrInvalidQueryStrategy = errors.New("query strategy should be DEFAULT or UNION_ALL")
+	ErrClauseConflict       = errors.New("query should not have ORDER BY, LIMIT or OFFSET, which are built by paginator")
+	ErrNoCoveringIndex      = errors.New("there is no index covering rules of paginator")
+	ErrNonUniqueRules       = errors.New("rules should identify rows uniquely, e.g. end with primary key")
+	ErrInvalidDest          = errors.New("destination should be a pointer to slice")
+	ErrNoPrimaryKey         = errors.New("model should have primary key for batch update or delete")
+	ErrUpdatePagingKey      = errors.New("batch update should not change columns of paging keys")
+	ErrInvalidPartitions    = errors.New("number of partitions should be greater than 0")
+	ErrInvalidSyncOrder     = errors.New("sync mode needs ASC order on the first rule")
+	ErrMissingDestField     = errors.New("dest should have fields holding values of paging keys for cursor")
+	ErrMissingSelectColumn  = errors.New("query should select columns or aliases of paging keys")
 )
@@ -0,0 +1,157 @@
+package paginator
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FollowHandler handles newly found rows, which are already loaded into dest given to Follower.Follow.
+// cursor is the after cursor of the last row, which can be used to resume following.
+type FollowHandler func(cursor string) error
+
+// NewFollower creates follower, opts are applied to the paginator of every poll.
+// Order of the paginator is always ASC, so that polls walk towards new rows.
+func NewFollower(opts ...Option) *Follower {
+	opts = append(opts[:len(opts):len(opts)], WithOrder(ASC))
+	return &Follower{
+		opts:        opts,
+		interval:    time.Second,
+		maxInterval: 30 * time.Second,
+		cursor:      New(opts...).cursor.After,
+	}
+}
+
+// Follower polls a query for rows coming after its after cursor, like "tail -f".
+// It is meant for rows keyed by increasing values (e.g. ID or creation time) in ASC order,
+// rules with their own Order should keep it ASC as well.
+type Follower struct {
+	opts        []Option
+	interval    time.Duration
+	maxInterval time.Duration
+
+	mu     sync.RWMutex
+	cursor *string
+}
+
+// SetInterval sets the duration to wait before polling again after an empty poll
+func (f *Follower) SetInterval(interval time.Duration) {
+	f.interval = interval
+}
+
+// SetMaxInterval sets the upper bound of the waiting duration, which doubles after each consecutive empty poll
+func (f *Follower) SetMaxInterval(maxInterval time.Duration) {
+	f.maxInterval = maxInterval
+}
+
+// Cursor returns the cursor after the last row followed so far, it is kept even when polls return nothing
+func (f *Follower) Cursor() Cursor {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return Cursor{After: f.cursor}
+}
+
+// Follow polls for new rows and passes them to handler until context of db is done or an error occurs.
+// A full page is followed by the next poll immediately, dest must be a pointer to slice.
+func (f *Follower) Follow(db *gorm.DB, dest interface{}, handler FollowHandler) error {
+	if !isSlicePtr(dest) {
+		return ErrInvalidDest
+	}
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	wait := f.interval
+	for {
+		found, hasMore, err := f.poll(db, dest, handler)
+		if err != nil {
+			return err
+		}
+		if found {
+			wait = f.interval
+		}
+		if hasMore {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		if !found {
+			wait *= 2
+			if wait > f.maxInterval {
+				wait = f.maxInterval
+			}
+		}
+	}
+}
+
+// FollowChan is like Follow, but delivers new rows through the returned channel. Each value
+// sent is a pointer to a new slice of dest type. Both channels are closed when following stops,
+// and the error channel receives the reason why it stops.
+func (f *Follower) FollowChan(db *gorm.DB, dest interface{}) (<-chan interface{}, <-chan error) {
+	rowsCh := make(chan interface{})
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(errCh)
+		defer close(rowsCh)
+		ctx := db.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		errCh <- f.Follow(db, dest, func(cursor string) error {
+			elems := reflect.ValueOf(dest).Elem()
+			rows := reflect.New(elems.Type())
+			rows.Elem().Set(reflect.AppendSlice(rows.Elem(), elems))
+			select {
+			case rowsCh <- rows.Interface():
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return rowsCh, errCh
+}
+
+/* private */
+
+func (f *Follower) poll(db *gorm.DB, dest interface{}, handler FollowHandler) (found bool, hasMore bool, err error) {
+	opts := f.opts
+	if c := f.Cursor(); c.After != nil {
+		opts = append(opts[:len(opts):len(opts)], WithAfter(*c.After))
+	}
+	p := New(opts...)
+	result, c, err := p.Paginate(db.Session(&gorm.Session{}), dest)
+	if err != nil {
+		return
+	}
+	if err = result.Error; err != nil {
+		return
+	}
+	elems := reflect.ValueOf(dest).Elem()
+	if elems.Len() == 0 {
+		// keep the cursor we already have
+		return
+	}
+	// after cursor is only returned when there are more rows,
+	// so encode it from the last row on our own
+	last, err := p.encodeCursor(elems, true)
+	if err != nil {
+		return
+	}
+	if err = handler(*last.After); err != nil {
+		return
+	}
+	f.mu.Lock()
+	f.cursor = last.After
+	f.mu.Unlock()
+	return true, c.After != nil, nil
+}
@@ -0,0 +1,347 @@
+package paginator
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/pilagod/gorm-cursor-paginator/v2/internal/util"
+)
+
+// IndexAdvice is an index whose columns and directions match paging rules
+type IndexAdvice struct {
+	Name  string
+	Table string
+	// Elements are columns or expressions of the index with directions, in the order of rules
+	Elements []string
+	// DDL is the CREATE INDEX statement of the index
+	DDL string
+}
+
+// AdviseIndex advises the index for paginating dest, including expression index
+// for rules having NULLReplacement or SQLType.
+func (p *Paginator) AdviseIndex(db *gorm.DB, dest interface{}) (*IndexAdvice, error) {
+	s, elems, err := p.clone().getIndexElements(db, dest)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(elems))
+	elements := make([]string, len(elems))
+	for i, elem := range elems {
+		names[i] = elem.name
+		elements[i] = elem.build(db)
+	}
+	advice := &IndexAdvice{
+		Name:     fmt.Sprintf("idx_%s_%s", s.Table, strings.Join(names, "_")),
+		Table:    s.Table,
+		Elements: elements,
+	}
+	advice.DDL = fmt.Sprintf(
+		"CREATE INDEX %s ON %s (%s)",
+		db.Statement.Quote(advice.Name),
+		db.Statement.Quote(advice.Table),
+		strings.Join(advice.Elements, ", "),
+	)
+	return advice, nil
+}
+
+// CheckIndex finds existing index covering paging rules, which is either primary key or index
+// in database. Indexes are read from catalog of PostgreSQL, MySQL and SQLite, so that indexes
+// created by migrations or by hand are found as well, while for other dialects only indexes
+// declared in model are checked. It returns name of the index, or ErrNoCoveringIndex when
+// there is none.
+func (p *Paginator) CheckIndex(db *gorm.DB, dest interface{}) (string, error) {
+	s, elems, err := p.clone().getIndexElements(db, dest)
+	if err != nil {
+		return "", err
+	}
+	model := db.Statement.Model
+	if model == nil {
+		model = reflect.New(util.ReflectType(dest)).Interface()
+	}
+	if len(s.PrimaryFields) > 0 && db.Migrator().HasTable(model) {
+		fields := make([]schema.IndexOption, len(s.PrimaryFields))
+		for i, f := range s.PrimaryFields {
+			fields[i] = schema.IndexOption{Field: f}
+		}
+		if coversIndexElements(fields, elems) {
+			return "PRIMARY KEY", nil
+		}
+	}
+	indexes, err := loadIndexes(db, s, model)
+	if err != nil {
+		return "", err
+	}
+	names := make([]string, 0, len(indexes))
+	for name := range indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if coversIndexElements(indexes[name], elems) {
+			return name, nil
+		}
+	}
+	return "", ErrNoCoveringIndex
+}
+
+/* private */
+
+type indexElement struct {
+	name string
+	// column is set when element is a plain column
+	column     string
+	expr       string
+	order      Order
+	nullsOrder NullsOrder
+	native     bool
+}
+
+func (e indexElement) build(db *gorm.DB) string {
+	var sql string
+	if e.column != "" {
+		sql = fmt.Sprintf("%s %s", db.Statement.Quote(e.column), e.order)
+	} else {
+		sql = fmt.Sprintf("(%s) %s", e.expr, e.order)
+	}
+	if e.nullsOrder != "" && e.native {
+		sql = fmt.Sprintf("%s %s", sql, e.nullsOrder)
+	}
+	return sql
+}
+
+func (p *Paginator) clone() *Paginator {
+	c := *p
+	if p.rules != nil {
+		c.rules = make([]Rule, len(p.rules))
+		copy(c.rules, p.rules)
+	}
+	return &c
+}
+
+func (p *Paginator) getIndexElements(db *gorm.DB, dest interface{}) (*schema.Schema, []indexElement, error) {
+	if err := p.validate(db, dest); err != nil {
+		return nil, nil, err
+	}
+	// keep rules before setup, whose SQL representations are not qualified by table yet
+	rules := make([]Rule, len(p.rules))
+	copy(rules, p.rules)
+	if err := p.setup(db, dest); err != nil {
+		return nil, nil, err
+	}
+	s, err := parseModelSchema(db, dest)
+	if err != nil {
+		return nil, nil, err
+	}
+	elems := make([]indexElement, len(p.rules))
+	for i, rule := range p.rules {
+		// rules appended by setup are tiebreakers
+		raw := Rule{Key: rule.Key}
+		if i < len(rules) {
+			raw = rules[i]
+		}
+		field, ok := lookUpKey(s, rule.Key)
+		if !ok || len(field.relations) > 0 {
+			// column of joined table cannot be covered by index of model table
+			return nil, nil, ErrNoCoveringIndex
+		}
+		elem := indexElement{
+			name:       field.DBName,
+			order:      rule.Order,
+			nullsOrder: rule.NullsOrder,
+			native:     p.nativeNullsOrder,
+		}
+		if raw.SQLRepr == "" && raw.NULLReplacement == nil && raw.SQLType == nil {
+			elem.column = field.DBName
+		} else if raw.SQLRepr == "" {
+			elem.expr = raw.wrapSQLReprLiteral(db.Statement.Quote(field.DBName))
+		} else {
+			// DDL takes no bound parameters, index on expression is only for the same vars
+			elem.expr = raw.wrapSQLReprLiteral(inlineSQLVars(raw.SQLRepr, raw.SQLVars))
+		}
+		elems[i] = elem
+	}
+	return s, elems, nil
+}
+
+// coversIndexElements tells whether leading fields of index match elements, index can be
+// scanned in either direction, so directions should be all the same or all opposite.
+func coversIndexElements(fields []schema.IndexOption, elems []indexElement) bool {
+	if len(fields) < len(elems) {
+		return false
+	}
+	var flipped *bool
+	for i, elem := range elems {
+		f := fields[i]
+		if elem.column != "" {
+			if f.Expression != "" || f.Field == nil || f.Field.DBName != elem.column {
+				return false
+			}
+		} else if normalizeSQL(f.Expression) != normalizeSQL(elem.expr) {
+			return false
+		}
+		flip := (strings.ToUpper(f.Sort) == "DESC") != (elem.order == DESC)
+		if flipped == nil {
+			flipped = &flip
+		} else if *flipped != flip {
+			return false
+		}
+	}
+	return true
+}
+
+// loadIndexes returns fields of indexes on table of model by their names
+func loadIndexes(db *gorm.DB, s *schema.Schema, model interface{}) (map[string][]schema.IndexOption, error) {
+	tx := db.Session(&gorm.Session{NewDB: true})
+	switch db.Dialector.Name() {
+	case "postgres":
+		return loadIndexDefs(tx.Raw(
+			"SELECT indexname, indexdef FROM pg_indexes WHERE tablename = ? AND schemaname = ANY (current_schemas(false))",
+			s.Table,
+		))
+	case "sqlite":
+		indexes, err := loadIndexDefs(tx.Raw("SELECT name, sql FROM sqlite_master WHERE type = 'index' AND tbl_name = ?", s.Table))
+		if err != nil {
+			return nil, err
+		}
+		for name, fields := range indexes {
+			if fields != nil {
+				continue
+			}
+			// indexes created for UNIQUE and PRIMARY KEY constraints have no definition
+			var columns []string
+			if err := tx.Raw("SELECT name FROM pragma_index_info(?) ORDER BY seqno", name).Scan(&columns).Error; err != nil {
+				return nil, err
+			}
+			for _, column := range columns {
+				indexes[name] = append(indexes[name], schema.IndexOption{Field: &schema.Field{DBName: column}})
+			}
+		}
+		return indexes, nil
+	case "mysql":
+		return loadMySQLIndexes(tx, s.Table)
+	}
+	// indexes declared in model and created in database
+	indexes := make(map[string][]schema.IndexOption)
+	for name, index := range s.ParseIndexes() {
+		if db.Migrator().HasIndex(model, name) {
+			indexes[name] = index.Fields
+		}
+	}
+	return indexes, nil
+}
+
+// loadIndexDefs loads indexes by rows of name and definition, indexes without definition are left nil
+func loadIndexDefs(stmt *gorm.DB) (map[string][]schema.IndexOption, error) {
+	rows, err := stmt.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	indexes := make(map[string][]schema.IndexOption)
+	for rows.Next() {
+		var name string
+		var def sql.NullString
+		if err := rows.Scan(&name, &def); err != nil {
+			return nil, err
+		}
+		indexes[name] = parseIndexDef(def.String)
+	}
+	return indexes, rows.Err()
+}
+
+func loadMySQLIndexes(tx *gorm.DB, table string) (map[string][]schema.IndexOption, error) {
+	rows, err := tx.Raw(
+		"SELECT index_name, column_name, collation FROM information_schema.statistics "+
+			"WHERE table_schema = DATABASE() AND table_name = ? ORDER BY index_name, seq_in_index",
+		table,
+	).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	indexes := make(map[string][]schema.IndexOption)
+	for rows.Next() {
+		var name string
+		var column, collation sql.NullString
+		if err := rows.Scan(&name, &column, &collation); err != nil {
+			return nil, err
+		}
+		// parts on expressions have no column, which never cover elements
+		var field schema.IndexOption
+		if column.Valid {
+			field.Field = &schema.Field{DBName: column.String}
+		}
+		if collation.String == "D" {
+			field.Sort = "DESC"
+		}
+		indexes[name] = append(indexes[name], field)
+	}
+	return indexes, rows.Err()
+}
+
+// parseIndexDef parses fields of index from its definition, e.g. "CREATE INDEX idx ON orders (created_at DESC, id)",
+// it returns nil for empty definition
+func parseIndexDef(def string) []schema.IndexOption {
+	on := strings.Index(strings.ToLower(def), " on ")
+	if on < 0 {
+		return nil
+	}
+	open := strings.Index(def[on:], "(")
+	if open < 0 {
+		return nil
+	}
+	body := def[on+open+1:]
+	// element list ends at the parenthesis closing the opening one
+	end, depth := -1, 0
+	for i := 0; i < len(body) && end < 0; i++ {
+		switch body[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				end = i
+			}
+			depth--
+		}
+	}
+	if end < 0 {
+		return nil
+	}
+	items := splitSelectItems(body[:end])
+	fields := make([]schema.IndexOption, len(items))
+	for i, item := range items {
+		fields[i] = parseIndexElement(item)
+	}
+	return fields
+}
+
+// parseIndexElement parses element of index, e.g. "created_at DESC NULLS LAST" or "lower(remark)"
+func parseIndexElement(item string) schema.IndexOption {
+	words := strings.Fields(item)
+	// nulls order is not checked, neither is it by coversIndexElements
+	if n := len(words); n > 2 && strings.EqualFold(words[n-2], "NULLS") {
+		words = words[:n-2]
+	}
+	var field schema.IndexOption
+	if n := len(words); n > 1 && (strings.EqualFold(words[n-1], "ASC") || strings.EqualFold(words[n-1], "DESC")) {
+		field.Sort = strings.ToUpper(words[n-1])
+		words = words[:n-1]
+	}
+	expr := strings.Join(words, " ")
+	if isColumnRef(expr) {
+		field.Field = &schema.Field{DBName: columnName(expr)}
+	} else {
+		field.Expression = expr
+	}
+	return field
+}
+
+func normalizeSQL(sql string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "\"", "", "`", "", "(", "", ")", "").Replace(sql))
+}
@@ -0,0 +1,76 @@
+package paginator
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// keyField is the field which key of rule refers to
+type keyField struct {
+	*schema.Field
+	// relations are names of relationships walked through to reach the field,
+	// e.g. ["Order"] for key "Order.CreatedAt"
+	relations []string
+}
+
+// lookUpKey resolves key on schema, key is either name or column of a field, a path through
+// embedded structs, e.g. "Audit.CreatedAt", or a dotted path through belongs-to or has-one
+// relationships, e.g. "Order.CreatedAt"
+func lookUpKey(s *schema.Schema, key string) (*keyField, bool) {
+	names := strings.Split(key, ".")
+	var relations []string
+	for {
+		if f := lookUpField(s, strings.Join(names, ".")); f != nil {
+			return &keyField{Field: f, relations: relations}, true
+		}
+		if len(names) == 1 {
+			return nil, false
+		}
+		rel, ok := s.Relationships.Relations[names[0]]
+		if !ok || (rel.Type != schema.BelongsTo && rel.Type != schema.HasOne) {
+			return nil, false
+		}
+		relations = append(relations, rel.Name)
+		s, names = rel.FieldSchema, names[1:]
+	}
+}
+
+// lookUpField looks up field by path through embedded structs, name or column. Field of model
+// itself comes first by name, while schema.LookUpField prefers field of embedded struct.
+func lookUpField(s *schema.Schema, name string) *schema.Field {
+	for _, f := range s.Fields {
+		if strings.Join(f.BindNames, ".") == name {
+			return f
+		}
+	}
+	return s.LookUpField(name)
+}
+
+// keyOf returns key referring to field, which is name of field when it is reachable by name,
+// or path through embedded structs otherwise, e.g. "Audit.CreatedAt" when model has its own CreatedAt
+func keyOf(s *schema.Schema, f *schema.Field) string {
+	if lookUpField(s, f.Name) == f {
+		return f.Name
+	}
+	return strings.Join(f.BindNames, ".")
+}
+
+// path returns path of key field on model, which cursor reads values by
+func (k *keyField) path() string {
+	return strings.Join(append(append([]string{}, k.relations...), k.BindNames...), ".")
+}
+
+// quoteKeyColumn quotes column of key. Joins aliases joined table by relation name, and
+// selects its columns as "<relation>__<column>", which is what derived table exposes.
+func (p *Paginator) quoteKeyColumn(db *gorm.DB, table string, key *keyField) string {
+	if len(key.relations) == 0 {
+		return quoteColumn(db, table, key.DBName)
+	}
+	alias := strings.Join(key.relations, "__")
+	if p.derivedTable != "" {
+		return quoteColumn(db, p.derivedTable, alias+"__"+key.DBName)
+	}
+	return quoteColumn(db, alias, key.DBName)
+}
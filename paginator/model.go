@@ -0,0 +1,78 @@
+package paginator
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/pilagod/gorm-cursor-paginator/v2/internal/util"
+)
+
+// parseModelSchema parses schema of queried model, which is the one set by db.Model, or dest
+// itself when there is none. It tells table and columns when dest is a DTO of the model.
+func parseModelSchema(db *gorm.DB, dest interface{}) (*schema.Schema, error) {
+	if model := db.Statement.Model; model != nil {
+		return util.ParseSchema(db, model)
+	}
+	return util.ParseSchema(db, dest)
+}
+
+// resolveKey resolves key on schema of model, or on schema of dest for keys which only dest has,
+// e.g. column selected by expression. It also returns path of field on dest holding value of key,
+// which is found by column when dest is a DTO of the model.
+func resolveKey(db *gorm.DB, dest interface{}, key string) (k *keyField, path string, err error) {
+	if isMapDest(dest) {
+		return resolveMapKey(db, key)
+	}
+	ms, err := parseModelSchema(db, dest)
+	if err != nil {
+		return nil, "", ErrInvalidModel
+	}
+	ds, err := util.ParseSchema(db, dest)
+	if err != nil {
+		return nil, "", ErrInvalidModel
+	}
+	k, ok := lookUpKey(ms, key)
+	if !ok {
+		if k, ok = lookUpKey(ds, key); !ok {
+			return nil, "", ErrInvalidModel
+		}
+	}
+	if ms == ds {
+		return k, k.path(), nil
+	}
+	if len(k.relations) == 0 {
+		if f := ds.LookUpField(k.DBName); f != nil {
+			return k, (&keyField{Field: f}).path(), nil
+		}
+	} else if dk, ok := lookUpKey(ds, key); ok {
+		return k, dk.path(), nil
+	}
+	return nil, "", ErrMissingDestField
+}
+
+// isMapDest tells whether dest is map, e.g. *[]map[string]interface{}, which is scanned by columns
+func isMapDest(dest interface{}) bool {
+	return util.ReflectType(dest).Kind() == reflect.Map
+}
+
+// resolveMapKey resolves key for map dest, whose entries are keyed by columns. Key is resolved on
+// model when db has one, otherwise key is column of table of db.
+func resolveMapKey(db *gorm.DB, key string) (*keyField, string, error) {
+	if model := db.Statement.Model; model != nil {
+		s, err := util.ParseSchema(db, model)
+		if err != nil {
+			return nil, "", ErrInvalidModel
+		}
+		k, ok := lookUpKey(s, key)
+		if !ok || len(k.relations) > 0 {
+			return nil, "", ErrInvalidModel
+		}
+		return k, k.DBName, nil
+	}
+	if db.Statement.Table == "" || key == "" {
+		return nil, "", ErrInvalidModel
+	}
+	return &keyField{Field: &schema.Field{Name: key, DBName: key}}, key, nil
+}
@@ -0,0 +1,233 @@
+package paginator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/pilagod/gorm-cursor-paginator/v2/internal/util"
+)
+
+// BatchProgress reports progress of UpdateInBatches and DeleteInBatches
+type BatchProgress struct {
+	// Batch is the sequence number of the batch, starting from 1
+	Batch int
+	// RowsAffected is the number of rows affected by the batch
+	RowsAffected int64
+	// TotalRowsAffected is the number of rows affected so far
+	TotalRowsAffected int64
+	// Cursor is the after cursor of the last row in the batch, which can be used to resume
+	Cursor string
+}
+
+// UpdateInBatches applies values to rows matched by db in chunks of limit, walking
+// through rows in the order of paging rules. dest must be a pointer to slice of model,
+// or of DTO of model set by db.Model, which holds rows of current chunk when onProgress
+// is called. Values should not change columns of paging keys.
+func (p *Paginator) UpdateInBatches(
+	db *gorm.DB,
+	dest interface{},
+	values map[string]interface{},
+	onProgress func(BatchProgress),
+) (int64, error) {
+	return p.mutateInBatches(db, dest, onProgress, func(tx *gorm.DB, model interface{}) *gorm.DB {
+		return tx.Model(model).Updates(values)
+	}, func(s *schema.Schema) error {
+		for key := range values {
+			f := s.LookUpField(key)
+			if f == nil {
+				continue
+			}
+			for _, rule := range p.rules {
+				if k, ok := lookUpKey(s, rule.Key); ok && len(k.relations) == 0 && k.Field == f {
+					return ErrUpdatePagingKey
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteInBatches deletes rows matched by db in chunks of limit, walking through rows
+// in the order of paging rules. dest must be a pointer to slice of model, or of DTO
+// of model set by db.Model, which holds rows of current chunk when onProgress is called.
+func (p *Paginator) DeleteInBatches(
+	db *gorm.DB,
+	dest interface{},
+	onProgress func(BatchProgress),
+) (int64, error) {
+	return p.mutateInBatches(db, dest, onProgress, func(tx *gorm.DB, model interface{}) *gorm.DB {
+		return tx.Delete(model)
+	}, nil)
+}
+
+/* private */
+
+type mutateFunc func(tx *gorm.DB, model interface{}) *gorm.DB
+
+func (p *Paginator) mutateInBatches(
+	db *gorm.DB,
+	dest interface{},
+	onProgress func(BatchProgress),
+	mutate mutateFunc,
+	validate func(s *schema.Schema) error,
+) (total int64, err error) {
+	if !isSlicePtr(dest) {
+		return 0, ErrInvalidDest
+	}
+	if err = p.validate(db, dest); err != nil {
+		return
+	}
+	s, err := parseModelSchema(db, dest)
+	if err != nil {
+		return
+	}
+	if len(s.PrimaryFields) == 0 {
+		return 0, ErrNoPrimaryKey
+	}
+	if validate != nil {
+		if err = validate(s); err != nil {
+			return
+		}
+	}
+	if err = p.setup(db, dest); err != nil {
+		return
+	}
+	// batches always walk forward
+	p.cursor.Before = nil
+
+	model := db.Statement.Model
+	if model == nil {
+		model = reflect.New(util.ReflectType(dest)).Interface()
+	}
+	elems := reflect.ValueOf(dest).Elem()
+
+	for batch := 1; ; batch++ {
+		fields, err := p.decodeCursor(dest)
+		if err != nil {
+			return total, err
+		}
+		chunk := p.appendPagingQuery(db, fields, p.limit)
+
+		var rowsAffected int64
+		if supportsReturning(db) {
+			rowsAffected, err = p.mutateReturning(db, dest, s, chunk, model, mutate)
+		} else {
+			rowsAffected, err = p.mutateSelected(db, dest, s, chunk, model, mutate)
+		}
+		if err != nil {
+			return total, err
+		}
+		if elems.Len() == 0 {
+			return total, nil
+		}
+		total += rowsAffected
+
+		c, err := p.encodeCursor(elems, true)
+		if err != nil {
+			return total, err
+		}
+		p.cursor.After = c.After
+
+		if onProgress != nil {
+			onProgress(BatchProgress{
+				Batch:             batch,
+				RowsAffected:      rowsAffected,
+				TotalRowsAffected: total,
+				Cursor:            *c.After,
+			})
+		}
+		if elems.Len() < p.limit {
+			return total, nil
+		}
+	}
+}
+
+// mutateSelected selects rows of chunk first, and then mutates them by primary keys
+func (p *Paginator) mutateSelected(
+	db *gorm.DB,
+	dest interface{},
+	s *schema.Schema,
+	chunk *gorm.DB,
+	model interface{},
+	mutate mutateFunc,
+) (int64, error) {
+	// primary keys are read from dest, which may be a DTO of model
+	ds, err := util.ParseSchema(db, dest)
+	if err != nil {
+		return 0, err
+	}
+	fields := make([]*schema.Field, len(s.PrimaryFields))
+	for i, f := range s.PrimaryFields {
+		if fields[i] = ds.LookUpField(f.DBName); fields[i] == nil {
+			return 0, ErrMissingDestField
+		}
+	}
+	if err := chunk.Find(dest).Error; err != nil {
+		return 0, err
+	}
+	elems := reflect.ValueOf(dest).Elem()
+	if elems.Len() == 0 {
+		return 0, nil
+	}
+	keys := make([]interface{}, elems.Len())
+	for i := 0; i < elems.Len(); i++ {
+		elem := util.ReflectValue(elems.Index(i))
+		values := make([]interface{}, len(fields))
+		for j, f := range fields {
+			values[j], _ = f.ValueOf(elem)
+		}
+		if len(values) == 1 {
+			keys[i] = values[0]
+		} else {
+			keys[i] = values
+		}
+	}
+	tx := db.Session(&gorm.Session{NewDB: true}).Where(p.buildPrimaryKeyInSQL(db, s, "?"), keys)
+	result := mutate(tx, model)
+	return result.RowsAffected, result.Error
+}
+
+// mutateReturning mutates rows of chunk and gets them back by RETURNING in one statement
+func (p *Paginator) mutateReturning(
+	db *gorm.DB,
+	dest interface{},
+	s *schema.Schema,
+	chunk *gorm.DB,
+	model interface{},
+	mutate mutateFunc,
+) (int64, error) {
+	tx := db.Session(&gorm.Session{NewDB: true, DryRun: true}).
+		Where(p.buildPrimaryKeyInSQL(db, s, "(?)"), chunk.Model(model).Select(p.buildPrimaryKeyColumnsSQL(db, s)))
+	stmt := mutate(tx, model)
+	if stmt.Error != nil {
+		return 0, stmt.Error
+	}
+	// order returned rows by aliasing them as model table, so that
+	// SQL representations of rules can be used as they are
+	orderSQL, orderVars := p.buildOrderSQL()
+	result := db.Session(&gorm.Session{NewDB: true}).Raw(
+		fmt.Sprintf("WITH chunk AS (? RETURNING *) SELECT * FROM chunk AS ? ORDER BY %s", orderSQL),
+		append([]interface{}{stmt, clause.Table{Name: s.Table}}, orderVars...)...,
+	).Scan(dest)
+	return result.RowsAffected, result.Error
+}
+
+func (p *Paginator) buildPrimaryKeyColumnsSQL(db *gorm.DB, s *schema.Schema) string {
+	columns := make([]string, len(s.PrimaryFields))
+	for i, f := range s.PrimaryFields {
+		columns[i] = quoteColumn(db, s.Table, f.DBName)
+	}
+	return strings.Join(columns, ", ")
+}
+
+func (p *Paginator) buildPrimaryKeyInSQL(db *gorm.DB, s *schema.Schema, placeholder string) string {
+	if len(s.PrimaryFields) == 1 {
+		return fmt.Sprintf("%s IN %s", p.buildPrimaryKeyColumnsSQL(db, s), placeholder)
+	}
+	return fmt.Sprintf("(%s) IN %s", p.buildPrimaryKeyColumnsSQL(db, s), placeholder)
+}
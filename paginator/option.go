@@ -5,14 +5,17 @@ type Flag string
 const (
 	TRUE  Flag = "TRUE"
 	FALSE Flag = "FALSE"
+	AUTO  Flag = "AUTO"
 )
 
 var defaultConfig = Config{
-	Keys:          []string{"ID"},
-	Limit:         10,
-	Order:         DESC,
-	AllowTupleCmp: FALSE,
-	CursorCodec:   &JSONCursorCodec{},
+	Limit:          10,
+	Order:          DESC,
+	AllowTupleCmp:  FALSE,
+	CursorCodec:    &JSONCursorCodec{},
+	Tiebreaker:     TiebreakerNone,
+	ReplaceClauses: FALSE,
+	QueryStrategy:  QueryStrategyDefault,
 }
 
 // Option for paginator
@@ -22,14 +25,21 @@ type Option interface {
 
 // Config for paginator
 type Config struct {
-	Rules         []Rule
-	Keys          []string
-	Limit         int
-	Order         Order
-	After         string
-	Before        string
-	AllowTupleCmp Flag
-	CursorCodec   CursorCodec
+	Rules          []Rule
+	Keys           []string
+	Limit          int
+	Order          Order
+	After          string
+	Before         string
+	AllowTupleCmp  Flag
+	CursorCodec    CursorCodec
+	Tiebreaker     Tiebreaker
+	ReplaceClauses Flag
+	QueryStrategy  QueryStrategy
+	DerivedTable   string
+	Sync           Flag
+	SyncToken      string
+	SnapshotKey    string
 }
 
 // Apply applies config to paginator
@@ -54,11 +64,32 @@ func (c *Config) Apply(p *Paginator) {
 		p.SetBeforeCursor(c.Before)
 	}
 	if c.AllowTupleCmp != "" {
-		p.SetAllowTupleCmp(c.AllowTupleCmp == TRUE)
+		p.SetTupleCmp(c.AllowTupleCmp)
 	}
 	if c.CursorCodec != nil {
 		p.SetCursorCodec(c.CursorCodec)
 	}
+	if c.Tiebreaker != "" {
+		p.SetTiebreaker(c.Tiebreaker)
+	}
+	if c.ReplaceClauses != "" {
+		p.SetReplaceClauses(c.ReplaceClauses == TRUE)
+	}
+	if c.QueryStrategy != "" {
+		p.SetQueryStrategy(c.QueryStrategy)
+	}
+	if c.DerivedTable != "" {
+		p.SetDerivedTable(c.DerivedTable)
+	}
+	if c.Sync != "" {
+		p.SetSync(c.Sync == TRUE)
+	}
+	if c.SyncToken != "" {
+		p.SetSyncToken(c.SyncToken)
+	}
+	if c.SnapshotKey != "" {
+		p.SetSnapshotKey(c.SnapshotKey)
+	}
 }
 
 // WithRules configures rules for paginator
@@ -103,7 +134,7 @@ func WithBefore(c string) Option {
 	}
 }
 
-// WithAllowTupleCmp enables tuple comparison optimization
+// WithAllowTupleCmp enables tuple comparison optimization, or decides it by dialect with AUTO
 func WithAllowTupleCmp(flag Flag) Option {
 	return &Config{
 		AllowTupleCmp: flag,
@@ -116,3 +147,52 @@ func WithCursorCodec(codec CursorCodec) Option {
 		CursorCodec: codec,
 	}
 }
+
+// WithTiebreaker configures how to handle rules not known to be unique
+func WithTiebreaker(tiebreaker Tiebreaker) Option {
+	return &Config{
+		Tiebreaker: tiebreaker,
+	}
+}
+
+// WithReplaceClauses allows replacing ORDER BY, LIMIT and OFFSET of query
+func WithReplaceClauses(flag Flag) Option {
+	return &Config{
+		ReplaceClauses: flag,
+	}
+}
+
+// WithQueryStrategy configures the way of seeking rows after cursor
+func WithQueryStrategy(strategy QueryStrategy) Option {
+	return &Config{
+		QueryStrategy: strategy,
+	}
+}
+
+// WithDerivedTable wraps query as derived table of given alias, and paginates on the outer query
+func WithDerivedTable(alias string) Option {
+	return &Config{
+		DerivedTable: alias,
+	}
+}
+
+// WithSync enables sync mode
+func WithSync(flag Flag) Option {
+	return &Config{
+		Sync: flag,
+	}
+}
+
+// WithSyncToken configures sync token returned by last sync session, and enables sync mode
+func WithSyncToken(token string) Option {
+	return &Config{
+		SyncToken: token,
+	}
+}
+
+// WithSnapshotKey enables snapshot mode bounded by given key
+func WithSnapshotKey(key string) Option {
+	return &Config{
+		SnapshotKey: key,
+	}
+}
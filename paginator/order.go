@@ -22,3 +22,26 @@ func (o *Order) validate() error {
 	}
 	return nil
 }
+
+// NullsOrder type for position of NULL values
+type NullsOrder string
+
+// NullsOrders
+const (
+	NullsFirst NullsOrder = "NULLS FIRST"
+	NullsLast  NullsOrder = "NULLS LAST"
+)
+
+func (o *NullsOrder) flip() NullsOrder {
+	if *o == NullsFirst {
+		return NullsLast
+	}
+	return NullsFirst
+}
+
+func (o *NullsOrder) validate() error {
+	if *o != NullsFirst && *o != NullsLast {
+		return ErrInvalidNullsOrder
+	}
+	return nil
+}
@@ -1,14 +1,15 @@
 package paginator
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/pilagod/gorm-cursor-paginator/v2/cursor"
-	"github.com/pilagod/gorm-cursor-paginator/v2/internal/util"
 )
 
 // New creates paginator
@@ -22,12 +23,23 @@ func New(opts ...Option) *Paginator {
 
 // Paginator a builder doing pagination
 type Paginator struct {
-	cursor        Cursor
-	rules         []Rule
-	limit         int
-	order         Order
-	allowTupleCmp bool
-	cursorCodec   CursorCodec
+	cursor         Cursor
+	rules          []Rule
+	limit          int
+	order          Order
+	allowTupleCmp  Flag
+	cursorCodec    CursorCodec
+	tiebreaker     Tiebreaker
+	replaceClauses bool
+	queryStrategy  QueryStrategy
+	derivedTable   string
+	sync           syncState
+	snapshot       snapshotState
+
+	// nativeNullsOrder tells whether dialect supports NULLS FIRST / NULLS LAST
+	nativeNullsOrder bool
+	// tupleCmp tells whether tuple comparison is used, resolved from allowTupleCmp and dialect
+	tupleCmp bool
 }
 
 // SetRules sets paging rules
@@ -69,7 +81,17 @@ func (p *Paginator) SetBeforeCursor(beforeCursor string) {
 
 // SetAllowTupleCmp enables or disables tuple comparison optimization
 func (p *Paginator) SetAllowTupleCmp(allow bool) {
-	p.allowTupleCmp = allow
+	if allow {
+		p.allowTupleCmp = TRUE
+	} else {
+		p.allowTupleCmp = FALSE
+	}
+}
+
+// SetTupleCmp sets tuple comparison optimization to TRUE, FALSE or AUTO,
+// AUTO enables it only on dialects supporting row value comparison
+func (p *Paginator) SetTupleCmp(flag Flag) {
+	p.allowTupleCmp = flag
 }
 
 // SetCursorCodec sets custom cursor codec
@@ -77,19 +99,67 @@ func (p *Paginator) SetCursorCodec(codec CursorCodec) {
 	p.cursorCodec = codec
 }
 
+// SetTiebreaker sets how to handle rules not known to be unique
+func (p *Paginator) SetTiebreaker(tiebreaker Tiebreaker) {
+	p.tiebreaker = tiebreaker
+}
+
+// SetReplaceClauses allows or disallows replacing ORDER BY, LIMIT and OFFSET of query,
+// query having them fails with ErrClauseConflict when disallowed
+func (p *Paginator) SetReplaceClauses(replace bool) {
+	p.replaceClauses = replace
+}
+
+// SetQueryStrategy sets the way of seeking rows after cursor
+func (p *Paginator) SetQueryStrategy(strategy QueryStrategy) {
+	p.queryStrategy = strategy
+}
+
+// SetDerivedTable wraps query as derived table of given alias, and paginates on the outer query,
+// an empty alias disables it
+func (p *Paginator) SetDerivedTable(alias string) {
+	p.derivedTable = alias
+}
+
+// SetSync enables or disables sync mode
+func (p *Paginator) SetSync(enabled bool) {
+	p.sync.enabled = enabled
+}
+
+// SetSyncToken sets sync token returned by last sync session, and enables sync mode
+func (p *Paginator) SetSyncToken(token string) {
+	p.sync.enabled = true
+	p.sync.token = &token
+}
+
+// SyncToken returns fresh sync token for next sync session, it is only
+// available after the final page of a sync session is paginated
+func (p *Paginator) SyncToken() *string {
+	return p.sync.nextToken
+}
+
+// SetSnapshotKey enables snapshot mode bounded by given key
+func (p *Paginator) SetSnapshotKey(key string) {
+	p.snapshot.rule = Rule{Key: key}
+}
+
+// SnapshotNewRows returns number of rows beyond snapshot bound, i.e., rows
+// created after the first page of the snapshot session
+func (p *Paginator) SnapshotNewRows() int64 {
+	return p.snapshot.newRows
+}
+
 // Paginate paginates data
 func (p *Paginator) Paginate(db *gorm.DB, dest interface{}) (result *gorm.DB, c Cursor, err error) {
-	if err = p.validate(db, dest); err != nil {
-		return
-	}
-	if err = p.setup(db, dest); err != nil {
-		return
-	}
-	fields, err := p.decodeCursor(dest)
+	stmt, _, err := p.buildPagingQuery(db, dest)
 	if err != nil {
 		return
 	}
-	if result = p.appendPagingQuery(db, fields).Find(dest); result.Error != nil {
+	if result = stmt.Find(dest); result.Error != nil {
+		// clauses added by scopes of db are only checked when query executes
+		if errors.Is(result.Error, ErrClauseConflict) {
+			err = ErrClauseConflict
+		}
 		return
 	}
 	// dest must be a pointer type or gorm will panic above
@@ -107,67 +177,130 @@ func (p *Paginator) Paginate(db *gorm.DB, dest interface{}) (result *gorm.DB, c
 			return
 		}
 	}
+	if p.isSession() {
+		c, err = p.encodeSessionCursor(c, elems)
+	}
 	return
 }
 
 /* private */
 
+// buildPagingQuery composes query of the page on db, and returns it along with decoded cursor fields
+func (p *Paginator) buildPagingQuery(db *gorm.DB, dest interface{}) (stmt *gorm.DB, fields []interface{}, err error) {
+	if p.derivedTable != "" {
+		db = p.wrapDerivedTable(db, dest)
+	}
+	if err = p.validate(db, dest); err != nil {
+		return
+	}
+	if err = p.setup(db, dest); err != nil {
+		return
+	}
+	if p.isSession() {
+		if err = p.setupSession(db, dest); err != nil {
+			return
+		}
+	}
+	if fields, err = p.decodeCursor(dest); err != nil {
+		return
+	}
+	stmt = db.Session(&gorm.Session{})
+	if stmt, err = p.selectRuleColumns(stmt); err != nil {
+		return
+	}
+	if p.isSession() {
+		stmt = p.appendSessionQuery(stmt)
+	}
+	// rules of grouped query may be on aggregates, which cannot be ordered again outside of union
+	if p.queryStrategy == QueryStrategyUnionAll && len(fields) > 0 && !isGroupedQuery(stmt.Statement) {
+		stmt, err = p.appendUnionPagingQuery(stmt, dest, fields, p.limit+1)
+	} else {
+		stmt = p.appendPagingQuery(stmt, fields, p.limit+1)
+	}
+	return
+}
+
 func (p *Paginator) validate(db *gorm.DB, dest interface{}) (err error) {
+	// rules are derived from model when neither rules nor keys are specified
+	if p.rules == nil {
+		if err = p.setDefaultRules(db, dest); err != nil {
+			return
+		}
+	}
 	if len(p.rules) == 0 {
 		return ErrNoRule
 	}
+	// rules may have been set up by previous pagination
+	for i := range p.rules {
+		p.rules[i].reset()
+	}
+	p.snapshot.rule.reset()
 	if p.limit <= 0 {
 		return ErrInvalidLimit
 	}
 	if err = p.order.validate(); err != nil {
 		return
 	}
+	if err = p.tiebreaker.validate(); err != nil {
+		return
+	}
+	if err = p.queryStrategy.validate(); err != nil {
+		return
+	}
+	// check clauses in advance, clauses added by scopes are checked right before query executes
+	if err = p.checkClauses(db.Statement); err != nil {
+		return
+	}
 	for _, rule := range p.rules {
 		if err = rule.validate(db, dest); err != nil {
 			return
 		}
 	}
+	if p.snapshot.enabled() {
+		if err = p.snapshot.rule.validate(db, dest); err != nil {
+			return
+		}
+	}
 	return
 }
 
 func (p *Paginator) setup(db *gorm.DB, dest interface{}) error {
-	var sqlTable string
+	// dialect is only consulted when it matters, which may query version of database
+	p.nativeNullsOrder = p.hasNullsOrder() && supportsNullsOrder(db)
+	p.tupleCmp = p.allowTupleCmp == TRUE || (p.allowTupleCmp == AUTO && supportsTupleCmp(db))
 	for i := range p.rules {
-		rule := &p.rules[i]
-		if rule.SQLRepr == "" {
-			if sqlTable == "" {
-				schema, err := util.ParseSchema(db, dest)
-				if err != nil {
-					return err
-				}
-				sqlTable = schema.Table
-			}
-			sqlKey := p.parseSQLKey(db, dest, rule.Key)
-			rule.SQLRepr = fmt.Sprintf("%s.%s", sqlTable, sqlKey)
-		}
-
-		if rule.NULLReplacement != nil {
-			nullReplacement := fmt.Sprintf("'%v'", rule.NULLReplacement)
-			if rule.SQLType != nil {
-				nullReplacement = fmt.Sprintf("CAST(%s as %s)", nullReplacement, *rule.SQLType)
-			}
-			rule.SQLRepr = fmt.Sprintf("COALESCE(%s, %s)", rule.SQLRepr, nullReplacement)
-		}
-		// cast to the underlying SQL type
-		if rule.SQLType != nil {
-			rule.SQLRepr = fmt.Sprintf("CAST( %s AS %s )", rule.SQLRepr, *rule.SQLType)
-		}
-		if rule.Order == "" {
-			rule.Order = p.order
+		if err := p.setupRule(db, dest, &p.rules[i]); err != nil {
+			return err
 		}
 	}
-	return nil
+	return p.setupTiebreaker(db, dest)
 }
 
-func (p *Paginator) parseSQLKey(db *gorm.DB, dest interface{}, key string) string {
-	// dest is already validated at validataion phase
-	schema, _ := util.ParseSchema(db, dest)
-	return schema.LookUpField(key).DBName
+func (p *Paginator) setupRule(db *gorm.DB, dest interface{}, rule *Rule) error {
+	table, err := p.getTableName(db, dest)
+	if err != nil {
+		return err
+	}
+	key, path, err := resolveKey(db, dest, rule.Key)
+	if err != nil {
+		return err
+	}
+	rule.fieldPath = path
+	if len(key.relations) == 0 {
+		rule.dbName = key.DBName
+	}
+	if rule.SQLRepr == "" {
+		rule.SQLRepr = p.quoteKeyColumn(db, table, key)
+		rule.column = rule.SQLRepr
+	}
+	rule.rawSQLRepr = rule.SQLRepr
+	sqlRepr, vars := rule.wrapSQLRepr(rule.SQLRepr)
+	// vars of expression come before vars added by wrapping
+	rule.SQLRepr, rule.sqlVars = sqlRepr, append(append([]interface{}{}, rule.SQLVars...), vars...)
+	if rule.Order == "" {
+		rule.Order = p.order
+	}
+	return nil
 }
 
 // https://mangatmodi.medium.com/go-check-nil-interface-the-right-way-d142776edef1
@@ -186,13 +319,17 @@ func isNil(i interface{}) bool {
 
 func (p *Paginator) decodeCursor(dest interface{}) (result []interface{}, err error) {
 	if p.isForward() {
-		if result, err = p.cursorCodec.Decode(p.getDecoderFields(), *p.cursor.After, dest); err != nil {
-			err = ErrInvalidCursor
-		}
-	} else if p.isBackward() {
-		if result, err = p.cursorCodec.Decode(p.getDecoderFields(), *p.cursor.Before, dest); err != nil {
-			err = ErrInvalidCursor
-		}
+		return p.decodeFields(*p.cursor.After, dest)
+	}
+	if p.isBackward() {
+		return p.decodeFields(*p.cursor.Before, dest)
+	}
+	return
+}
+
+func (p *Paginator) decodeFields(c string, dest interface{}) (result []interface{}, err error) {
+	if result, err = p.cursorCodec.Decode(p.getDecoderFields(), c, dest); err != nil {
+		err = ErrInvalidCursor
 	}
 	// replace null values
 	for i := range result {
@@ -217,48 +354,285 @@ func (p *Paginator) isBackward() bool {
 	return !p.isForward() && p.cursor.Before != nil
 }
 
-func (p *Paginator) appendPagingQuery(db *gorm.DB, fields []interface{}) *gorm.DB {
-	stmt := db
-	stmt = stmt.Limit(p.limit + 1)
-	stmt = stmt.Order(p.buildOrderSQL())
+func (p *Paginator) appendPagingQuery(db *gorm.DB, fields []interface{}, limit int) *gorm.DB {
+	orderSQL, orderVars := p.buildOrderSQL()
+	clauses := []clause.Interface{
+		clause.OrderBy{Expression: clause.Expr{SQL: orderSQL, Vars: orderVars}},
+		clause.Limit{Limit: limit},
+	}
+	stmt := db.Session(&gorm.Session{}).Scopes(p.withClauses(clauses...))
+	if len(fields) > 0 {
+		query, args := p.buildKeysetSQLQuery(fields, p.isBackward())
+		stmt = stmt.Scopes(withCursorQuery(clause.Expr{SQL: query, Vars: args}))
+	}
+	return stmt
+}
 
-	if len(fields) == 0 {
-		return stmt
+// withCursorQuery returns scope adding cursor query to WHERE, or to HAVING when query has
+// GROUP BY, so that rules can be on aggregates (e.g. SUM(amount)) of grouped query.
+func withCursorQuery(expr clause.Expression) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if isGroupedQuery(db.Statement) {
+			db.Statement.AddClause(clause.GroupBy{Having: []clause.Expression{expr}})
+		} else {
+			db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{expr}})
+		}
+		return db
 	}
+}
+
+func isGroupedQuery(stmt *gorm.Statement) bool {
+	c, ok := stmt.Clauses["GROUP BY"]
+	return ok && c.Name != ""
+}
 
-	if p.allowTupleCmp && p.canOptimizePagingQuery() {
-		return stmt.Where(p.buildOptimizedCursorSQLQuery(), fields)
+// withClauses returns scope adding clauses built by paginator. Scopes are applied in order
+// right before query executes, so ORDER BY, LIMIT and OFFSET added by preceding scopes of
+// db can also be replaced, or be reported by ErrClauseConflict.
+func (p *Paginator) withClauses(clauses ...clause.Interface) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if err := p.checkClauses(db.Statement); err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+		for _, name := range managedClauses {
+			delete(db.Statement.Clauses, name)
+		}
+		for _, c := range clauses {
+			db.Statement.AddClause(c)
+		}
+		return db
 	}
+}
 
-	return stmt.Where(
-		p.buildCursorSQLQuery(),
-		p.buildCursorSQLQueryArgs(fields)...,
-	)
+// managedClauses are clauses built by paginator, OFFSET is part of LIMIT clause
+var managedClauses = []string{"ORDER BY", "LIMIT"}
+
+// checkClauses reports ErrClauseConflict when stmt already has clauses built by paginator,
+// unless replacing them is allowed
+func (p *Paginator) checkClauses(stmt *gorm.Statement) error {
+	if p.replaceClauses {
+		return nil
+	}
+	for _, name := range managedClauses {
+		if _, ok := stmt.Clauses[name]; ok {
+			return ErrClauseConflict
+		}
+	}
+	return nil
 }
 
-func (p *Paginator) buildOrderSQL() string {
+func (p *Paginator) buildOrderSQL() (string, []interface{}) {
 	orders := make([]string, len(p.rules))
+	var vars []interface{}
 	for i, rule := range p.rules {
 		order := rule.Order
 		if p.isBackward() {
 			order = order.flip()
 		}
 		orders[i] = fmt.Sprintf("%s %s", rule.SQLRepr, order)
+		vars = append(vars, rule.sqlVars...)
+		if rule.NullsOrder == "" {
+			continue
+		}
+		nullsOrder := rule.NullsOrder
+		if p.isBackward() {
+			nullsOrder = nullsOrder.flip()
+		}
+		if p.nativeNullsOrder {
+			orders[i] = fmt.Sprintf("%s %s", orders[i], nullsOrder)
+		} else {
+			// emulate by sorting on nullness first
+			nullRank := 0
+			if nullsOrder == NullsLast {
+				nullRank = 1
+			}
+			orders[i] = fmt.Sprintf(
+				"CASE WHEN %s IS NULL THEN %d ELSE %d END ASC, %s",
+				rule.SQLRepr, nullRank, 1-nullRank, orders[i],
+			)
+			// rule appears twice with the same vars
+			vars = append(vars, rule.sqlVars...)
+		}
+	}
+	return strings.Join(orders, ", "), vars
+}
+
+// buildKeysetSQLQuery builds query for rows coming after fields in the order of rules,
+// or coming before fields when backward.
+func (p *Paginator) buildKeysetSQLQuery(fields []interface{}, backward bool) (string, []interface{}) {
+	if p.hasNullsOrder() {
+		// tuple comparison and placeholders cannot compare NULL values
+		return p.buildNullableCursorSQLQuery(fields, backward)
+	}
+	if !p.canOptimizePagingQuery() {
+		return p.buildGroupedCursorSQLQuery(fields, backward)
 	}
-	return strings.Join(orders, ", ")
+	if p.tupleCmp {
+		return p.buildOptimizedCursorSQLQuery(fields, backward)
+	}
+	return p.buildCursorSQLQuery(fields, backward)
 }
 
-func (p *Paginator) buildCursorSQLQuery() string {
+func (p *Paginator) buildCursorSQLQuery(fields []interface{}, backward bool) (string, []interface{}) {
 	queries := make([]string, len(p.rules))
 	query := ""
+	var args, eqArgs []interface{}
 	for i, rule := range p.rules {
-		operator := p.getCmpOperator(rule.Order)
+		operator := getCmpOperator(rule.Order, backward)
 		queries[i] = fmt.Sprintf("%s%s %s ?", query, rule.SQLRepr, operator)
+		args = append(append(append(args, eqArgs...), rule.sqlVars...), fields[i])
 		query = fmt.Sprintf("%s%s = ? AND ", query, rule.SQLRepr)
+		eqArgs = append(append(eqArgs, rule.sqlVars...), fields[i])
 	}
 	// for exmaple:
 	// a > 1 OR a = 1 AND b > 2 OR a = 1 AND b = 2 AND c > 3
-	return strings.Join(queries, " OR ")
+	return strings.Join(queries, " OR "), args
+}
+
+// buildGroupedCursorSQLQuery builds cursor query for rules having mixed orders. It bounds
+// the leading rule by range so that index on it can be used, and compares consecutive rules
+// having the same order as a group, which is a partial tuple when tuple comparison is enabled.
+func (p *Paginator) buildGroupedCursorSQLQuery(fields []interface{}, backward bool) (string, []interface{}) {
+	var queries []string
+	var args []interface{}
+	eqQuery := ""
+	var eqArgs []interface{}
+	for start := 0; start < len(p.rules); {
+		end := start + 1
+		for end < len(p.rules) && p.rules[end].Order == p.rules[start].Order {
+			end++
+		}
+		rules, values := p.rules[start:end], fields[start:end]
+		operator := getCmpOperator(rules[0].Order, backward)
+
+		cmpQuery, cmpArgs := buildGroupCmpSQLQuery(rules, values, operator, p.tupleCmp)
+		queries = append(queries, eqQuery+cmpQuery)
+		args = append(append(args, eqArgs...), cmpArgs...)
+
+		groupEqQuery, groupEqArgs := buildGroupCmpSQLQuery(rules, values, "=", p.tupleCmp)
+		eqQuery = fmt.Sprintf("%s%s AND ", eqQuery, groupEqQuery)
+		eqArgs = append(eqArgs, groupEqArgs...)
+
+		start = end
+	}
+	// for example, when a, b are ASC and c is DESC:
+	// a >= 1 AND ((a, b) > (1, 2) OR (a, b) = (1, 2) AND c < 3)
+	query := fmt.Sprintf(
+		"%s %s= ? AND (%s)",
+		p.rules[0].SQLRepr,
+		getCmpOperator(p.rules[0].Order, backward),
+		strings.Join(queries, " OR "),
+	)
+	return query, append(p.rules[0].bindVars(fields[0]), args...)
+}
+
+// buildGroupCmpSQLQuery compares rules with values by operator, as a tuple when allowed
+func buildGroupCmpSQLQuery(rules []Rule, values []interface{}, operator string, tupleCmp bool) (string, []interface{}) {
+	if len(rules) == 1 {
+		return fmt.Sprintf("%s %s ?", rules[0].SQLRepr, operator), rules[0].bindVars(values[0])
+	}
+	names := make([]string, len(rules))
+	var vars []interface{}
+	for i, rule := range rules {
+		names[i] = rule.SQLRepr
+		vars = append(vars, rule.sqlVars...)
+	}
+	if tupleCmp {
+		return fmt.Sprintf("(%s) %s ?", strings.Join(names, ", "), operator), append(vars, values)
+	}
+	var queries []string
+	var args []interface{}
+	if operator == "=" {
+		for i, name := range names {
+			queries = append(queries, fmt.Sprintf("%s = ?", name))
+			args = append(append(args, rules[i].sqlVars...), values[i])
+		}
+		return strings.Join(queries, " AND "), args
+	}
+	query := ""
+	var eqArgs []interface{}
+	for i, name := range names {
+		queries = append(queries, fmt.Sprintf("%s%s %s ?", query, name, operator))
+		args = append(append(append(args, eqArgs...), rules[i].sqlVars...), values[i])
+		query = fmt.Sprintf("%s%s = ? AND ", query, name)
+		eqArgs = append(append(eqArgs, rules[i].sqlVars...), values[i])
+	}
+	return fmt.Sprintf("(%s)", strings.Join(queries, " OR ")), args
+}
+
+func (p *Paginator) hasNullsOrder() bool {
+	for _, rule := range p.rules {
+		if rule.NullsOrder != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// buildNullableCursorSQLQuery builds cursor query with IS NULL / IS NOT NULL branches for rules
+// having nulls order, the query depends on which fields are NULL.
+func (p *Paginator) buildNullableCursorSQLQuery(fields []interface{}, backward bool) (string, []interface{}) {
+	queries, branchArgs := p.buildCursorSQLQueryBranches(fields, backward)
+	if len(queries) == 0 {
+		// cursor is at the end, e.g. NULL value placed last on the only rule
+		return "1 = 0", nil
+	}
+	var args []interface{}
+	for _, a := range branchArgs {
+		args = append(args, a...)
+	}
+	// for example, when a is NULLS LAST and cursor has NULL on a:
+	// a IS NULL AND b > 2
+	// when a is NULLS LAST and cursor has 1 on a:
+	// (a > 1 OR a IS NULL) OR a = 1 AND b > 2
+	return strings.Join(queries, " OR "), args
+}
+
+// buildCursorSQLQueryBranches builds cursor query as branches, one for each prefix of rules,
+// rows matching any of branches come after fields. It takes care of rules having nulls order.
+func (p *Paginator) buildCursorSQLQueryBranches(fields []interface{}, backward bool) (queries []string, args [][]interface{}) {
+	var eqQuery string
+	var eqArgs []interface{}
+	for i, rule := range p.rules {
+		cmpQuery, cmpArgs := buildNullableCmpSQLQuery(rule, fields[i], backward)
+		if cmpQuery != "" {
+			queries = append(queries, fmt.Sprintf("%s%s", eqQuery, cmpQuery))
+			args = append(args, append(append([]interface{}{}, eqArgs...), cmpArgs...))
+		}
+		eqArgs = append(eqArgs, rule.sqlVars...)
+		if rule.NullsOrder != "" && fields[i] == nil {
+			eqQuery = fmt.Sprintf("%s%s IS NULL AND ", eqQuery, rule.SQLRepr)
+		} else {
+			eqQuery = fmt.Sprintf("%s%s = ? AND ", eqQuery, rule.SQLRepr)
+			eqArgs = append(eqArgs, fields[i])
+		}
+	}
+	return
+}
+
+// buildNullableCmpSQLQuery builds query for rows coming strictly after field on given rule,
+// an empty query means there is no such row.
+func buildNullableCmpSQLQuery(rule Rule, field interface{}, backward bool) (string, []interface{}) {
+	operator := getCmpOperator(rule.Order, backward)
+	cmpArgs := rule.bindVars(field)
+	if rule.NullsOrder == "" {
+		return fmt.Sprintf("%s %s ?", rule.SQLRepr, operator), cmpArgs
+	}
+	nullsOrder := rule.NullsOrder
+	if backward {
+		nullsOrder = nullsOrder.flip()
+	}
+	if field == nil {
+		if nullsOrder == NullsFirst {
+			return fmt.Sprintf("%s IS NOT NULL", rule.SQLRepr), rule.sqlVars
+		}
+		return "", nil
+	}
+	if nullsOrder == NullsLast {
+		return fmt.Sprintf("(%s %s ? OR %s IS NULL)", rule.SQLRepr, operator, rule.SQLRepr), append(cmpArgs, rule.sqlVars...)
+	}
+	return fmt.Sprintf("%s %s ?", rule.SQLRepr, operator), cmpArgs
 }
 
 // We can only optimize paging query if sorting orders are consistent across
@@ -276,33 +650,29 @@ func (p *Paginator) canOptimizePagingQuery() bool {
 	return true
 }
 
-func (p *Paginator) getCmpOperator(order Order) string {
-	if (p.isForward() && order == ASC) || (p.isBackward() && order == DESC) {
+func getCmpOperator(order Order, backward bool) string {
+	if (!backward && order == ASC) || (backward && order == DESC) {
 		return ">"
 	}
 
 	return "<"
 }
 
-func (p *Paginator) buildOptimizedCursorSQLQuery() string {
+func (p *Paginator) buildOptimizedCursorSQLQuery(fields []interface{}, backward bool) (string, []interface{}) {
 	names := make([]string, len(p.rules))
+	var args []interface{}
 
 	for i, rule := range p.rules {
 		names[i] = rule.SQLRepr
+		args = append(args, rule.sqlVars...)
 	}
 
-	return fmt.Sprintf(
+	query := fmt.Sprintf(
 		"(%s) %s ?",
 		strings.Join(names, ", "),
-		p.getCmpOperator(p.rules[0].Order),
+		getCmpOperator(p.rules[0].Order, backward),
 	)
-}
-
-func (p *Paginator) buildCursorSQLQueryArgs(fields []interface{}) (args []interface{}) {
-	for i := 1; i <= len(fields); i++ {
-		args = append(args, fields[:i]...)
-	}
-	return
+	return query, append(args, fields)
 }
 
 func (p *Paginator) encodeCursor(elems reflect.Value, hasMore bool) (result Cursor, err error) {
@@ -330,10 +700,7 @@ func (p *Paginator) encodeCursor(elems reflect.Value, hasMore bool) (result Curs
 func (p *Paginator) getEncoderFields() []cursor.EncoderField {
 	fields := make([]cursor.EncoderField, len(p.rules))
 	for i, rule := range p.rules {
-		fields[i].Key = rule.Key
-		if rule.CustomType != nil {
-			fields[i].Meta = rule.CustomType.Meta
-		}
+		fields[i] = rule.getEncoderField()
 	}
 	return fields
 }
@@ -341,10 +708,7 @@ func (p *Paginator) getEncoderFields() []cursor.EncoderField {
 func (p *Paginator) getDecoderFields() []cursor.DecoderField {
 	fields := make([]cursor.DecoderField, len(p.rules))
 	for i, rule := range p.rules {
-		fields[i].Key = rule.Key
-		if rule.CustomType != nil {
-			fields[i].Type = &rule.CustomType.Type
-		}
+		fields[i] = rule.getDecoderField()
 	}
 	return fields
 }
@@ -0,0 +1,118 @@
+package paginator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// itemCount is the number of items of an order, aggregated by grouped query on items
+type itemCount struct {
+	OrderID int
+	Total   int
+}
+
+var itemCountRules = []Rule{
+	{Key: "Total", SQLRepr: "COUNT(*)"},
+	{Key: "OrderID", SQLRepr: "items.order_id"},
+}
+
+func TestBuildQueryGroupedUsesHaving(t *testing.T) {
+	t.Parallel()
+
+	after := encodeCursor(t, []string{"Total", "OrderID"}, itemCount{OrderID: 2, Total: 3})
+
+	db := dryRunDB(t)
+	stmt := db.Table("items").
+		Select("order_id, COUNT(*) AS total").
+		Where("items.name <> ?", "").
+		Group("order_id").
+		Having("COUNT(*) > ?", 0)
+
+	var counts []itemCount
+	q, err := New(
+		WithRules(itemCountRules...),
+		WithAfter(after),
+		WithAllowTupleCmp(TRUE),
+	).BuildQuery(stmt, &counts)
+	if assert.Nil(t, err) {
+		assert.Equal(
+			t,
+			"SELECT order_id, COUNT(*) AS total FROM items WHERE items.name <> ? "+
+				"GROUP BY order_id HAVING COUNT(*) > ? AND ((COUNT(*), items.order_id) < (?,?)) "+
+				"ORDER BY COUNT(*) DESC, items.order_id DESC LIMIT 11",
+			q.SQL,
+		)
+		assert.Equal(t, []interface{}{"", 0, 3, 2}, q.Vars)
+	}
+}
+
+func TestBuildQueryGroupedModelSkipsTiebreaker(t *testing.T) {
+	t.Parallel()
+
+	db := dryRunDB(t)
+	stmt := db.Model(&item{}).Select("order_id, COUNT(*) AS total").Group("order_id")
+
+	var counts []itemCount
+	q, err := New(WithRules(itemCountRules...)).BuildQuery(stmt, &counts)
+	if assert.Nil(t, err) {
+		assert.Equal(
+			t,
+			"SELECT order_id, COUNT(*) AS total FROM items GROUP BY order_id "+
+				"ORDER BY COUNT(*) DESC, items.order_id DESC LIMIT 11",
+			q.SQL,
+		)
+	}
+}
+
+func (s *paginatorSuite) TestPaginateGroupedQuery() {
+	orders := s.givenOrders(5)
+	for i, n := range []int{2, 3, 1, 3, 2} {
+		s.givenItems(orders[i], n)
+	}
+
+	for _, strategy := range []QueryStrategy{QueryStrategyDefault, QueryStrategyUnionAll} {
+		cfg := Config{
+			Rules:         itemCountRules,
+			Limit:         2,
+			QueryStrategy: strategy,
+		}
+		stmt := s.db.Table("items").Select("order_id, COUNT(*) AS total").Group("order_id")
+		s.assertGroupedPages(&cfg, stmt)
+		// model of grouped query has primary key, which is not appended as tiebreaker
+		s.assertGroupedPages(&cfg, s.db.Model(&item{}).Select("order_id, COUNT(*) AS total").Group("order_id"))
+	}
+}
+
+func (s *paginatorSuite) assertGroupedPages(cfg *Config, stmt *gorm.DB) {
+	var p1 []itemCount
+	_, c, err := New(cfg).Paginate(stmt, &p1)
+	s.Nil(err)
+	s.assertOrderIDs(p1, 4, 2)
+	s.assertForwardOnly(c)
+
+	var p2 []itemCount
+	_, c, err = New(cfg, WithAfter(*c.After)).Paginate(stmt, &p2)
+	s.Nil(err)
+	s.assertOrderIDs(p2, 5, 1)
+	s.assertBothDirections(c)
+
+	var p3 []itemCount
+	_, c, err = New(cfg, WithAfter(*c.After)).Paginate(stmt, &p3)
+	s.Nil(err)
+	s.assertOrderIDs(p3, 3)
+	s.assertBackwardOnly(c)
+
+	var p2Back []itemCount
+	_, _, err = New(cfg, WithBefore(*c.Before)).Paginate(stmt, &p2Back)
+	s.Nil(err)
+	s.assertOrderIDs(p2Back, 5, 1)
+}
+
+func (s *paginatorSuite) assertOrderIDs(counts []itemCount, expectedIDs ...int) {
+	s.Len(counts, len(expectedIDs))
+	for i, c := range counts {
+		s.Equal(expectedIDs[i], c.OrderID)
+	}
+}
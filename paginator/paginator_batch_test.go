@@ -0,0 +1,141 @@
+package paginator
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+func (s *paginatorSuite) TestBatchRunnerRunsThroughAllRows() {
+	s.givenOrders(25)
+
+	var orders []order
+	var ids []int
+	var batches int
+
+	err := NewBatchRunner(
+		"orders",
+		NewMemoryCheckpointStore(),
+		WithLimit(10),
+		WithOrder(ASC),
+	).Run(s.db, &orders, func(tx *gorm.DB, batch int) error {
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+		batches = batch
+		return nil
+	})
+	s.Nil(err)
+	s.Equal(3, batches)
+	s.Equal(intRange(1, 25), ids)
+}
+
+func (s *paginatorSuite) TestBatchRunnerResumesFromCheckpoint() {
+	s.givenOrders(25)
+
+	store := NewMemoryCheckpointStore()
+	runner := NewBatchRunner("orders", store, WithLimit(10), WithOrder(ASC))
+
+	var orders []order
+	var ids []int
+
+	errStop := errors.New("stop")
+	err := runner.Run(s.db, &orders, func(tx *gorm.DB, batch int) error {
+		if batch == 2 {
+			return errStop
+		}
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+		return nil
+	})
+	s.Equal(errStop, err)
+	s.Equal(intRange(1, 10), ids)
+
+	ids = nil
+	err = runner.Run(s.db, &orders, func(tx *gorm.DB, batch int) error {
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+		return nil
+	})
+	s.Nil(err)
+	s.Equal(intRange(11, 25), ids)
+
+	// only rows created after last checkpoint are picked up
+	ids = nil
+	s.givenOrders(1)
+	err = runner.Run(s.db, &orders, func(tx *gorm.DB, batch int) error {
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+		return nil
+	})
+	s.Nil(err)
+	s.Equal([]int{26}, ids)
+}
+
+func (s *paginatorSuite) TestBatchRunnerWithTransactionAndGormCheckpointStore() {
+	s.givenOrders(15)
+
+	store := NewGormCheckpointStore(s.db)
+	s.Nil(store.Migrate())
+	defer s.db.Migrator().DropTable(&Checkpoint{})
+
+	runner := NewBatchRunner("orders", store, WithLimit(10), WithOrder(ASC))
+	runner.SetTransaction(true)
+
+	var orders []order
+
+	errStop := errors.New("stop")
+	err := runner.Run(s.db, &orders, func(tx *gorm.DB, batch int) error {
+		ids := make([]int, len(orders))
+		for i, o := range orders {
+			ids[i] = o.ID
+		}
+		if err := tx.Model(&order{}).Where("id IN ?", ids).Update("remark", "done").Error; err != nil {
+			return err
+		}
+		if batch == 2 {
+			return errStop
+		}
+		return nil
+	})
+	s.Equal(errStop, err)
+
+	// second batch is rolled back along with its checkpoint
+	var count int64
+	s.db.Model(&order{}).Where("remark = ?", "done").Count(&count)
+	s.Equal(int64(10), count)
+
+	checkpoint, err := store.Load("orders")
+	s.Nil(err)
+	s.NotNil(checkpoint)
+
+	var p1 []order
+	_, _, _ = New(
+		WithLimit(10),
+		WithOrder(ASC),
+		WithAfter(*checkpoint),
+	).Paginate(s.db, &p1)
+	s.assertIDRange(p1, 11, 15)
+}
+
+func (s *paginatorSuite) TestBatchRunnerInvalidDest() {
+	var o order
+	err := NewBatchRunner(
+		"orders",
+		NewMemoryCheckpointStore(),
+	).Run(s.db, &o, func(tx *gorm.DB, batch int) error {
+		return nil
+	})
+	s.Equal(ErrInvalidDest, err)
+
+	err = NewBatchRunner(
+		"orders",
+		NewMemoryCheckpointStore(),
+	).Run(s.db, nil, func(tx *gorm.DB, batch int) error {
+		return nil
+	})
+	s.Equal(ErrInvalidDest, err)
+}
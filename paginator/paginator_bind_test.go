@@ -0,0 +1,135 @@
+package paginator
+
+import (
+	"testing"
+
+	"github.com/pilagod/pointer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildQueryBindsNULLReplacement(t *testing.T) {
+	t.Parallel()
+
+	c := encodeCursor(t, []string{"Remark", "ID"}, order{ID: 2})
+
+	db := dryRunDB(t)
+	var orders []order
+	q, err := New(
+		WithRules(
+			Rule{Key: "Remark", NULLReplacement: "it's", SQLType: pointer.String("varchar")},
+			Rule{Key: "ID"},
+		),
+		WithAfter(c),
+		WithAllowTupleCmp(TRUE),
+	).BuildQuery(db, &orders)
+	if assert.Nil(t, err) {
+		remark := "CAST( COALESCE(orders.remark, CAST(? as varchar)) AS varchar )"
+		assert.Equal(
+			t,
+			"SELECT * FROM orders WHERE ("+remark+", orders.id) < (?,?) "+
+				"ORDER BY "+remark+" DESC, orders.id DESC LIMIT 11",
+			q.SQL,
+		)
+		assert.Equal(t, []interface{}{"it's", "it's", 2, "it's"}, q.Vars)
+	}
+}
+
+func TestIsAllowedSQLType(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		sqlType string
+		want    bool
+	}{
+		{sqlType: "varchar", want: true},
+		{sqlType: "VARCHAR(30)", want: true},
+		{sqlType: "numeric(10, 2)", want: true},
+		{sqlType: "double  precision", want: true},
+		{sqlType: "timestamp with time zone", want: true},
+		{sqlType: "character varying (255)", want: true},
+		{sqlType: "varchar); DROP TABLE orders; --", want: false},
+		{sqlType: "text' OR '1'='1", want: false},
+		{sqlType: "unknown_type", want: false},
+		{sqlType: "", want: false},
+	}
+
+	for _, c := range tc {
+		assert.Equal(t, c.want, isAllowedSQLType(c.sqlType), "sql type: %s", c.sqlType)
+	}
+}
+
+func TestAllowSQLTypes(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, isAllowedSQLType("paginator_test_type"))
+	AllowSQLTypes("Paginator_Test_Type")
+	assert.True(t, isAllowedSQLType("paginator_test_type(8)"))
+}
+
+func (s *paginatorSuite) TestPaginateReplaceNULLWithQuote() {
+	s.givenOrders([]order{
+		{ID: 1, Remark: ptrStr("a")},
+		{ID: 2, Remark: nil},
+		{ID: 3, Remark: ptrStr("z")},
+		{ID: 4, Remark: nil},
+	})
+
+	cfg := Config{
+		Rules: []Rule{
+			{Key: "Remark", NULLReplacement: "it's", SQLType: pointer.String("varchar")},
+			{Key: "ID"},
+		},
+		Limit: 2,
+	}
+
+	var p1 []order
+	_, c, err := New(&cfg).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 3, 4)
+	s.assertForwardOnly(c)
+
+	var p2 []order
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 2, 1)
+	s.assertBackwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateReusedPaginator() {
+	s.givenOrders([]order{
+		{ID: 1, Remark: ptrStr("a")},
+		{ID: 2, Remark: nil},
+		{ID: 3, Remark: ptrStr("z")},
+		{ID: 4, Remark: nil},
+	})
+
+	p := New(
+		WithRules(
+			Rule{
+				Key:             "Remark",
+				SQLRepr:         "orders.remark || ?",
+				SQLVars:         []interface{}{""},
+				NULLReplacement: "it's",
+				SQLType:         pointer.String("varchar"),
+			},
+			Rule{Key: "ID"},
+		),
+		WithLimit(2),
+	)
+	// rules set up by the first pagination are set up again by the second one
+	for i := 0; i < 2; i++ {
+		var orders []order
+		_, c, err := p.Paginate(s.db, &orders)
+		s.Nil(err)
+		s.assertIDs(orders, 3, 4)
+		s.assertForwardOnly(c)
+	}
+}
+
+func (s *paginatorSuite) TestPaginateInvalidSQLType() {
+	var orders []order
+	_, _, err := New(
+		WithRules(Rule{Key: "Remark", SQLType: pointer.String("varchar); DROP TABLE orders; --")}),
+	).Paginate(s.db, &orders)
+	s.Equal(ErrInvalidSQLType, err)
+}
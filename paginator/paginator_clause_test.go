@@ -0,0 +1,76 @@
+package paginator
+
+import "gorm.io/gorm"
+
+func (s *paginatorSuite) TestPaginateClauseConflict() {
+	var orders []order
+	_, _, err := New().Paginate(s.db.Order("id ASC"), &orders)
+	s.Equal(ErrClauseConflict, err)
+
+	_, _, err = New().Paginate(s.db.Limit(1), &orders)
+	s.Equal(ErrClauseConflict, err)
+
+	_, _, err = New().Paginate(s.db.Offset(1), &orders)
+	s.Equal(ErrClauseConflict, err)
+}
+
+func (s *paginatorSuite) TestPaginateClauseConflictFromScopes() {
+	s.givenOrders(3)
+
+	var orders []order
+	_, _, err := New().Paginate(s.db.Scopes(func(db *gorm.DB) *gorm.DB {
+		return db.Limit(1)
+	}), &orders)
+	s.Equal(ErrClauseConflict, err)
+	s.Len(orders, 0)
+}
+
+func (s *paginatorSuite) TestPaginateReplaceClauses() {
+	s.givenOrders(5)
+
+	db := s.db.
+		Order("id ASC").
+		Limit(1).
+		Offset(2).
+		Scopes(func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC").Limit(4)
+		})
+	cfg := Config{
+		Limit:          2,
+		ReplaceClauses: TRUE,
+	}
+
+	var p1 []order
+	_, c, err := New(&cfg).Paginate(db, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 5, 4)
+	s.assertForwardOnly(c)
+
+	var p2 []order
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(db, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 3, 2)
+	s.assertBothDirections(c)
+}
+
+func (s *paginatorSuite) TestPaginateReuseQuery() {
+	s.givenOrders(5)
+
+	// paging clauses should not be left in query
+	db := s.db.Where("id > ?", 1)
+
+	var p1 []order
+	_, c, err := New(WithLimit(2)).Paginate(db, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 5, 4)
+
+	var p2 []order
+	_, c, err = New(WithLimit(2), WithAfter(*c.After)).Paginate(db, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 3, 2)
+	s.assertBackwardOnly(c)
+
+	var count int64
+	s.Nil(db.Model(&order{}).Count(&count).Error)
+	s.EqualValues(4, count)
+}
@@ -0,0 +1,93 @@
+package paginator
+
+import "time"
+
+type codeOrder struct {
+	Code   int     `gorm:"column:id;primaryKey"`
+	Remark *string `gorm:"type:varchar(30)"`
+}
+
+func (codeOrder) TableName() string {
+	return "orders"
+}
+
+type compositeKeyOrder struct {
+	Remark string `gorm:"primaryKey"`
+	ID     int    `gorm:"primaryKey"`
+}
+
+func (compositeKeyOrder) TableName() string {
+	return "orders"
+}
+
+type taggedOrder struct {
+	ID        int       `gorm:"primaryKey" paginate:"priority=2"`
+	CreatedAt time.Time `paginate:"order=asc,priority=1"`
+}
+
+func (taggedOrder) TableName() string {
+	return "orders"
+}
+
+func (s *paginatorSuite) TestPaginateDefaultRulesFromPrimaryKey() {
+	s.givenOrders(3)
+
+	var p1 []codeOrder
+	_, c, err := New(WithLimit(2)).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.Equal([]int{3, 2}, []int{p1[0].Code, p1[1].Code})
+	s.assertForwardOnly(c)
+
+	var p2 []codeOrder
+	_, c, err = New(WithLimit(2), WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.Len(p2, 1)
+	s.Equal(1, p2[0].Code)
+	s.assertBackwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateDefaultRulesFromCompositePrimaryKey() {
+	s.givenOrders([]order{
+		{ID: 1, Remark: ptrStr("b")},
+		{ID: 2, Remark: ptrStr("a")},
+		{ID: 3, Remark: ptrStr("b")},
+	})
+
+	var orders []compositeKeyOrder
+	_, _, err := New(WithOrder(ASC)).Paginate(s.db, &orders)
+	s.Nil(err)
+	s.Equal([]compositeKeyOrder{
+		{Remark: "a", ID: 2},
+		{Remark: "b", ID: 1},
+		{Remark: "b", ID: 3},
+	}, orders)
+}
+
+func (s *paginatorSuite) TestPaginateDefaultRulesFromTags() {
+	createdAt := time.Now()
+	s.givenOrders([]order{
+		{ID: 1, CreatedAt: createdAt.Add(time.Hour)},
+		{ID: 2, CreatedAt: createdAt},
+		{ID: 3, CreatedAt: createdAt},
+	})
+
+	var p1 []taggedOrder
+	_, c, err := New(WithLimit(2)).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 3, 2)
+	s.assertForwardOnly(c)
+
+	var p2 []taggedOrder
+	_, c, err = New(WithLimit(2), WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 1)
+	s.assertBackwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateInvalidTag() {
+	var orders []struct {
+		ID int `paginate:"order=sideways"`
+	}
+	_, _, err := New().Paginate(s.db, &orders)
+	s.Equal(ErrInvalidTag, err)
+}
@@ -0,0 +1,125 @@
+package paginator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// rankedOrder has rank computed by window function of derived table
+type rankedOrder struct {
+	ID   int `gorm:"primaryKey"`
+	Rank int `gorm:"->"`
+}
+
+func (rankedOrder) TableName() string {
+	return "orders"
+}
+
+func TestBuildQueryDerivedTable(t *testing.T) {
+	t.Parallel()
+
+	after := encodeCursor(t, []string{"ID"}, order{ID: 5})
+
+	db := dryRunDB(t)
+
+	tc := []struct {
+		stmt     *gorm.DB
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{
+			stmt:     db.Raw("SELECT * FROM orders WHERE id <> ?", 3),
+			wantSQL:  "SELECT * FROM (SELECT * FROM orders WHERE id <> ?) AS t WHERE t.id < ? ORDER BY t.id DESC LIMIT 11",
+			wantVars: []interface{}{3, 5},
+		},
+		{
+			stmt: db.Where("id <> ?", 3).Order("created_at").Limit(100),
+			wantSQL: "SELECT * FROM (SELECT * FROM orders WHERE id <> ? ORDER BY created_at LIMIT 100) AS t " +
+				"WHERE t.id < ? ORDER BY t.id DESC LIMIT 11",
+			wantVars: []interface{}{3, 5},
+		},
+	}
+
+	for _, c := range tc {
+		var orders []order
+		q, err := New(
+			WithKeys("ID"),
+			WithAfter(after),
+			WithDerivedTable("t"),
+		).BuildQuery(c.stmt, &orders)
+		if assert.Nil(t, err) {
+			assert.Equal(t, c.wantSQL, q.SQL)
+			assert.Equal(t, c.wantVars, q.Vars)
+		}
+	}
+}
+
+func (s *paginatorSuite) TestPaginateDerivedTableRaw() {
+	s.givenOrders(6)
+
+	for _, stmt := range []*gorm.DB{
+		s.db.Raw("SELECT * FROM orders WHERE id <> ?", 3),
+		s.db.Raw("SELECT * FROM orders WHERE id < ? UNION ALL SELECT * FROM orders WHERE id > ?", 3, 3),
+		s.db.Raw("WITH picked AS (SELECT * FROM orders WHERE id <> ?) SELECT * FROM picked", 3),
+	} {
+		cfg := Config{
+			Keys:         []string{"ID"},
+			Limit:        2,
+			DerivedTable: "t",
+		}
+
+		var p1 []order
+		_, c, err := New(&cfg).Paginate(stmt, &p1)
+		s.Nil(err)
+		s.assertIDs(p1, 6, 5)
+		s.assertForwardOnly(c)
+
+		var p2 []order
+		_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(stmt, &p2)
+		s.Nil(err)
+		s.assertIDs(p2, 4, 2)
+		s.assertBothDirections(c)
+
+		var p3 []order
+		_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(stmt, &p3)
+		s.Nil(err)
+		s.assertIDs(p3, 1)
+		s.assertBackwardOnly(c)
+
+		var p2Back []order
+		_, _, err = New(&cfg, WithBefore(*c.Before)).Paginate(stmt, &p2Back)
+		s.Nil(err)
+		s.assertIDs(p2Back, 4, 2)
+	}
+}
+
+func (s *paginatorSuite) TestPaginateDerivedTableWindowFunction() {
+	s.givenOrders(5)
+
+	// rank orders from the oldest, and paginate by rank from the newest
+	stmt := s.db.Model(&order{}).Select("id, ROW_NUMBER() OVER (ORDER BY id DESC) AS rank")
+
+	for _, strategy := range []QueryStrategy{QueryStrategyDefault, QueryStrategyUnionAll} {
+		cfg := Config{
+			Keys:          []string{"Rank"},
+			Order:         ASC,
+			Limit:         3,
+			DerivedTable:  "ranked",
+			QueryStrategy: strategy,
+		}
+
+		var p1 []rankedOrder
+		_, c, err := New(&cfg).Paginate(stmt, &p1)
+		s.Nil(err)
+		s.assertIDs(p1, 5, 4, 3)
+		s.assertForwardOnly(c)
+
+		var p2 []rankedOrder
+		_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(stmt, &p2)
+		s.Nil(err)
+		s.assertIDs(p2, 2, 1)
+		s.assertBackwardOnly(c)
+	}
+}
@@ -0,0 +1,214 @@
+package paginator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// fakeDialector generates SQL for dialect of given name and version without database
+type fakeDialector struct {
+	name    string
+	version string
+}
+
+func (d fakeDialector) Name() string {
+	return d.name
+}
+
+func (d fakeDialector) Version() string {
+	return d.version
+}
+
+func (d fakeDialector) Initialize(db *gorm.DB) error {
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+
+func (d fakeDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return nil
+}
+
+func (d fakeDialector) DataTypeOf(field *schema.Field) string {
+	return string(field.DataType)
+}
+
+func (d fakeDialector) DefaultValueOf(field *schema.Field) clause.Expression {
+	return clause.Expr{SQL: "DEFAULT"}
+}
+
+func (d fakeDialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	writer.WriteByte('?')
+}
+
+func (d fakeDialector) QuoteTo(writer clause.Writer, str string) {
+	writer.WriteString(str)
+}
+
+func (d fakeDialector) Explain(sql string, vars ...interface{}) string {
+	return logger.ExplainSQL(sql, nil, `'`, vars...)
+}
+
+func TestTupleCmpByDialect(t *testing.T) {
+	t.Parallel()
+
+	const (
+		tupleSQL    = "WHERE (orders.created_at, orders.id) > (?,?)"
+		expandedSQL = "WHERE orders.created_at > ? OR orders.created_at = ? AND orders.id > ?"
+	)
+
+	tc := []struct {
+		dialector fakeDialector
+		flag      Flag
+		want      string
+	}{
+		{dialector: fakeDialector{name: "postgres"}, flag: AUTO, want: tupleSQL},
+		{dialector: fakeDialector{name: "mysql"}, flag: AUTO, want: tupleSQL},
+		{dialector: fakeDialector{name: "sqlite", version: "3.15.0"}, flag: AUTO, want: tupleSQL},
+		{dialector: fakeDialector{name: "sqlite", version: "3.35.5"}, flag: AUTO, want: tupleSQL},
+		{dialector: fakeDialector{name: "sqlite", version: "3.14.2"}, flag: AUTO, want: expandedSQL},
+		{dialector: fakeDialector{name: "sqlite"}, flag: AUTO, want: expandedSQL},
+		{dialector: fakeDialector{name: "sqlserver"}, flag: AUTO, want: expandedSQL},
+		{dialector: fakeDialector{name: "sqlserver"}, flag: TRUE, want: tupleSQL},
+		{dialector: fakeDialector{name: "postgres"}, flag: FALSE, want: expandedSQL},
+	}
+
+	after := encodeCursor(t, []string{"CreatedAt", "ID"}, order{ID: 1, CreatedAt: time.Now()})
+
+	for _, c := range tc {
+		db, err := gorm.Open(c.dialector, &gorm.Config{DryRun: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var orders []order
+		result, _, err := New(
+			WithKeys("CreatedAt", "ID"),
+			WithOrder(ASC),
+			WithAfter(after),
+			WithAllowTupleCmp(c.flag),
+		).Paginate(db, &orders)
+		if assert.Nil(t, err) {
+			assert.Contains(
+				t,
+				result.Statement.SQL.String(),
+				c.want,
+				"dialect: %s %s, flag: %s", c.dialector.name, c.dialector.version, c.flag,
+			)
+		}
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		version string
+		want    bool
+	}{
+		{version: "3.15.0", want: true},
+		{version: "3.15", want: true},
+		{version: "3.9.1", want: false},
+		{version: "4.0.0", want: true},
+		{version: "2.99.0", want: false},
+		{version: "", want: false},
+		{version: "unknown", want: false},
+	}
+
+	for _, c := range tc {
+		assert.Equal(t, c.want, versionAtLeast(c.version, 3, 15), "version: %s", c.version)
+	}
+}
+
+func (s *paginatorSuite) TestPaginateAutoTupleCmp() {
+	s.givenOrders(5)
+
+	cfg := Config{
+		Keys:          []string{"CreatedAt", "ID"},
+		Limit:         2,
+		Order:         ASC,
+		AllowTupleCmp: AUTO,
+	}
+
+	var p1 []order
+	_, c, err := New(&cfg).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 1, 2)
+
+	var p2 []order
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 3, 4)
+	s.assertBothDirections(c)
+}
+
+func TestSQLiteVersionCachedBySQLDB(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	version := dialectVersion(db)
+	assert.True(t, versionAtLeast(version, 3, 0), version)
+
+	countEntries := func() (n int) {
+		sqliteVersions.Range(func(key, value interface{}) bool {
+			if key == sqlDB {
+				n++
+			}
+			return true
+		})
+		return
+	}
+	// sessions copy config, but share *sql.DB
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, version, dialectVersion(db.WithContext(context.Background())))
+	}
+	assert.Equal(t, 1, countEntries())
+
+	// transactions are not cached
+	tx, err := sqlDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	txDB := db.Session(&gorm.Session{})
+	txDB.Config.ConnPool = tx
+	assert.Equal(t, version, dialectVersion(txDB))
+	_, ok := sqliteVersions.Load(tx)
+	assert.False(t, ok)
+}
+
+func TestSQLiteVersionOnlyQueriedWhenNeeded(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var orders []order
+	_, err = New(WithKeys("CreatedAt", "ID"), WithAllowTupleCmp(FALSE)).BuildQuery(db, &orders)
+	assert.Nil(t, err)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ok := sqliteVersions.Load(sqlDB)
+	assert.False(t, ok)
+
+	_, err = New(
+		WithRules(Rule{Key: "Remark", NullsOrder: NullsLast}, Rule{Key: "ID"}),
+		WithAllowTupleCmp(FALSE),
+	).BuildQuery(db, &orders)
+	assert.Nil(t, err)
+	_, ok = sqliteVersions.Load(sqlDB)
+	assert.True(t, ok)
+}
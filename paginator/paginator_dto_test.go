@@ -0,0 +1,151 @@
+package paginator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// orderSummary is a DTO of order, it has no table name of its own
+type orderSummary struct {
+	ID      int
+	Created time.Time `gorm:"column:created_at"`
+}
+
+func TestBuildQueryDTODest(t *testing.T) {
+	t.Parallel()
+
+	db := dryRunDB(t)
+	var summaries []orderSummary
+	q, err := New(WithKeys("CreatedAt", "ID")).BuildQuery(db.Model(&order{}), &summaries)
+	if assert.Nil(t, err) {
+		// gorm selects columns of DTO from table of model
+		assert.Equal(
+			t,
+			"SELECT orders.id,orders.created_at FROM orders ORDER BY orders.created_at DESC, orders.id DESC LIMIT 11",
+			q.SQL,
+		)
+	}
+}
+
+func TestBuildQueryTableDTODest(t *testing.T) {
+	t.Parallel()
+
+	db := dryRunDB(t)
+	var summaries []orderSummary
+
+	q, err := New(WithKeys("Created", "ID")).BuildQuery(db.Table("orders"), &summaries)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "SELECT * FROM orders ORDER BY orders.created_at DESC, orders.id DESC LIMIT 11", q.SQL)
+	}
+
+	q, err = New(WithKeys("Created", "ID")).BuildQuery(db.Table("orders AS o"), &summaries)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "SELECT * FROM orders AS o ORDER BY o.created_at DESC, o.id DESC LIMIT 11", q.SQL)
+	}
+}
+
+func (s *paginatorSuite) TestPaginateTableDTODest() {
+	s.givenOrders(3)
+
+	cfg := Config{
+		Keys:  []string{"ID"},
+		Limit: 2,
+	}
+
+	var p1 []orderSummary
+	_, c, err := New(&cfg).Paginate(s.db.Table("orders"), &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 3, 2)
+
+	var p2 []orderSummary
+	result, _, err := New(&cfg, WithAfter(*c.After)).Paginate(s.db.Table("orders"), &p2)
+	s.Nil(err)
+	s.Nil(result.Error)
+	s.assertIDs(p2, 1)
+}
+
+func (s *paginatorSuite) TestPaginateDTODest() {
+	now := time.Now()
+	s.givenOrders([]order{
+		{ID: 1, CreatedAt: now.Add(1 * time.Hour)},
+		{ID: 2, CreatedAt: now.Add(3 * time.Hour)},
+		{ID: 3, CreatedAt: now.Add(2 * time.Hour)},
+	})
+
+	cfg := Config{
+		Keys:  []string{"CreatedAt"},
+		Limit: 2,
+	}
+
+	var p1 []orderSummary
+	_, c, err := New(&cfg).Paginate(s.db.Model(&order{}), &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 2, 3)
+	s.assertForwardOnly(c)
+
+	var p2 []orderSummary
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db.Model(&order{}), &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 1)
+	s.assertBackwardOnly(c)
+
+	var p1Back []orderSummary
+	_, _, err = New(&cfg, WithBefore(*c.Before)).Paginate(s.db.Model(&order{}), &p1Back)
+	s.Nil(err)
+	s.assertIDs(p1Back, 2, 3)
+}
+
+func (s *paginatorSuite) TestPaginateDTODestMissingKeyField() {
+	var summaries []orderSummary
+	_, _, err := New(WithKeys("Remark", "ID")).Paginate(s.db.Model(&order{}), &summaries)
+	s.Equal(ErrMissingDestField, err)
+
+	_, _, err = New(WithKeys("Unknown")).Paginate(s.db.Model(&order{}), &summaries)
+	s.Equal(ErrInvalidModel, err)
+}
+
+func (s *paginatorSuite) TestPaginateDTODestSnapshot() {
+	s.givenOrders(3)
+
+	cfg := Config{
+		Keys:        []string{"ID"},
+		Limit:       2,
+		SnapshotKey: "ID",
+	}
+
+	var p1 []orderSummary
+	_, c, err := New(&cfg).Paginate(s.db.Model(&order{}), &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 3, 2)
+
+	s.givenOrders([]order{{ID: 4}})
+
+	p := New(&cfg, WithAfter(*c.After))
+	var p2 []orderSummary
+	_, _, err = p.Paginate(s.db.Model(&order{}), &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 1)
+	s.Equal(int64(1), p.SnapshotNewRows())
+}
+
+func (s *paginatorSuite) TestCheckIndexDTODest() {
+	var summaries []orderSummary
+	name, err := New(WithKeys("ID")).CheckIndex(s.db.Model(&indexedOrder{}), &summaries)
+	s.Nil(err)
+	s.Equal("PRIMARY KEY", name)
+}
+
+func (s *paginatorSuite) TestDeleteInBatchesDTODest() {
+	s.givenOrders(5)
+
+	var summaries []orderSummary
+	rowsAffected, err := New(WithLimit(2)).DeleteInBatches(s.db.Model(&order{}).Where("id <= ?", 3), &summaries, nil)
+	s.Nil(err)
+	s.Equal(int64(3), rowsAffected)
+
+	var rest []order
+	s.db.Order("id").Find(&rest)
+	s.assertIDRange(rest, 4, 5)
+}
@@ -0,0 +1,143 @@
+package paginator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type orderAudit struct {
+	CreatedAt time.Time `paginate:"order=asc,priority=1"`
+}
+
+// auditedOrder has CreatedAt of orders in embedded struct
+type auditedOrder struct {
+	ID    int        `gorm:"primaryKey"`
+	Audit orderAudit `gorm:"embedded"`
+}
+
+func (auditedOrder) TableName() string {
+	return "orders"
+}
+
+// prefixedAuditOrder has its own CreatedAt besides the one in embedded struct with prefix
+type prefixedAuditOrder struct {
+	ID        int `gorm:"primaryKey"`
+	CreatedAt time.Time
+	Audit     orderAudit `gorm:"embedded;embeddedPrefix:audit_"`
+}
+
+func (prefixedAuditOrder) TableName() string {
+	return "orders"
+}
+
+// OrderBase is embedded anonymously, its fields are promoted to model
+type OrderBase struct {
+	ID        int `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+type baseOrder struct {
+	OrderBase
+	Remark *string
+}
+
+func (baseOrder) TableName() string {
+	return "orders"
+}
+
+func TestBuildQueryEmbeddedPrefixKey(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	after := encodeCursor(t, []string{"Audit.CreatedAt", "ID"}, prefixedAuditOrder{ID: 2, Audit: orderAudit{CreatedAt: createdAt}})
+
+	db := dryRunDB(t)
+	var orders []prefixedAuditOrder
+	q, err := New(
+		WithKeys("Audit.CreatedAt", "ID"),
+		WithAfter(after),
+		WithAllowTupleCmp(TRUE),
+	).BuildQuery(db, &orders)
+	if assert.Nil(t, err) {
+		assert.Equal(
+			t,
+			"SELECT * FROM orders WHERE (orders.audit_created_at, orders.id) < (?,?) "+
+				"ORDER BY orders.audit_created_at DESC, orders.id DESC LIMIT 11",
+			q.SQL,
+		)
+		assert.Equal(t, []interface{}{createdAt, 2}, q.Vars)
+	}
+
+	q, err = New(WithKeys("CreatedAt", "ID")).BuildQuery(db, &orders)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "SELECT * FROM orders ORDER BY orders.created_at DESC, orders.id DESC LIMIT 11", q.SQL)
+	}
+
+	// paginate tag on field of embedded struct with prefix
+	q, err = New(WithTiebreaker(TiebreakerAppend)).BuildQuery(db, &orders)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "SELECT * FROM orders ORDER BY orders.audit_created_at ASC, orders.id ASC LIMIT 11", q.SQL)
+	}
+}
+
+func (s *paginatorSuite) TestPaginateEmbeddedKey() {
+	now := time.Now()
+	s.givenOrders([]order{
+		{ID: 1, CreatedAt: now.Add(1 * time.Hour)},
+		{ID: 2, CreatedAt: now.Add(3 * time.Hour)},
+		{ID: 3, CreatedAt: now.Add(2 * time.Hour)},
+	})
+
+	for _, key := range []string{"Audit.CreatedAt", "CreatedAt", "created_at"} {
+		cfg := Config{
+			Keys:  []string{key, "ID"},
+			Limit: 2,
+		}
+
+		var p1 []auditedOrder
+		_, c, err := New(&cfg).Paginate(s.db, &p1)
+		s.Nil(err)
+		s.assertIDs(p1, 2, 3)
+		s.assertForwardOnly(c)
+
+		var p2 []auditedOrder
+		_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p2)
+		s.Nil(err)
+		s.assertIDs(p2, 1)
+		s.assertBackwardOnly(c)
+
+		var p1Back []auditedOrder
+		_, _, err = New(&cfg, WithBefore(*c.Before)).Paginate(s.db, &p1Back)
+		s.Nil(err)
+		s.assertIDs(p1Back, 2, 3)
+	}
+}
+
+func (s *paginatorSuite) TestPaginateAnonymousEmbeddedKey() {
+	now := time.Now()
+	s.givenOrders([]order{
+		{ID: 1, CreatedAt: now.Add(1 * time.Hour)},
+		{ID: 2, CreatedAt: now.Add(3 * time.Hour)},
+		{ID: 3, CreatedAt: now.Add(2 * time.Hour)},
+	})
+
+	// tiebreaker on promoted primary key is appended
+	cfg := Config{
+		Keys:  []string{"CreatedAt"},
+		Limit: 2,
+	}
+
+	var p1 []baseOrder
+	_, c, err := New(&cfg).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 2, 3)
+	s.assertForwardOnly(c)
+
+	var p2 []baseOrder
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 1)
+	s.assertBackwardOnly(c)
+}
@@ -0,0 +1,123 @@
+package paginator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// distanceOrder has distance scanned from expression selected in query
+type distanceOrder struct {
+	ID       int `gorm:"primaryKey"`
+	Distance int `gorm:"->"`
+}
+
+func (distanceOrder) TableName() string {
+	return "orders"
+}
+
+func TestBuildQueryExpressionVars(t *testing.T) {
+	t.Parallel()
+
+	after := encodeCursor(t, []string{"Distance", "ID"}, distanceOrder{ID: 3, Distance: 1})
+
+	tc := []struct {
+		flag     Flag
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{
+			flag: TRUE,
+			wantSQL: "SELECT * FROM orders WHERE (ABS(orders.id - ?), orders.id) > (?,?) " +
+				"ORDER BY ABS(orders.id - ?) ASC, orders.id ASC LIMIT 11",
+			wantVars: []interface{}{4, 1, 3, 4},
+		},
+		{
+			flag: FALSE,
+			wantSQL: "SELECT * FROM orders WHERE ABS(orders.id - ?) > ? OR ABS(orders.id - ?) = ? AND orders.id > ? " +
+				"ORDER BY ABS(orders.id - ?) ASC, orders.id ASC LIMIT 11",
+			wantVars: []interface{}{4, 1, 4, 1, 3, 4},
+		},
+	}
+
+	db := dryRunDB(t)
+	for _, c := range tc {
+		var orders []distanceOrder
+		q, err := New(
+			WithRules(
+				Rule{Key: "Distance", SQLRepr: "ABS(orders.id - ?)", SQLVars: []interface{}{4}},
+				Rule{Key: "ID"},
+			),
+			WithOrder(ASC),
+			WithAfter(after),
+			WithAllowTupleCmp(c.flag),
+		).BuildQuery(db, &orders)
+		if assert.Nil(t, err) {
+			assert.Equal(t, c.wantSQL, q.SQL)
+			assert.Equal(t, c.wantVars, q.Vars)
+		}
+	}
+}
+
+func (s *paginatorSuite) TestPaginateExpressionVars() {
+	s.givenOrders(7)
+
+	for _, strategy := range []QueryStrategy{QueryStrategyDefault, QueryStrategyUnionAll} {
+		for _, flag := range []Flag{TRUE, FALSE} {
+			cfg := Config{
+				Rules: []Rule{
+					{Key: "Distance", SQLRepr: "ABS(orders.id - ?)", SQLVars: []interface{}{4}},
+					{Key: "ID"},
+				},
+				Order:         ASC,
+				Limit:         3,
+				AllowTupleCmp: flag,
+				QueryStrategy: strategy,
+			}
+			stmt := s.db.Select("orders.*, ABS(orders.id - ?) AS distance", 4)
+
+			var p1 []distanceOrder
+			_, c, err := New(&cfg).Paginate(stmt, &p1)
+			s.Nil(err)
+			s.assertDistanceIDs(p1, 4, 3, 5)
+			s.assertForwardOnly(c)
+
+			var p2 []distanceOrder
+			_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(stmt, &p2)
+			s.Nil(err)
+			s.assertDistanceIDs(p2, 2, 6, 1)
+			s.assertBothDirections(c)
+
+			var p3 []distanceOrder
+			_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(stmt, &p3)
+			s.Nil(err)
+			s.assertDistanceIDs(p3, 7)
+			s.assertBackwardOnly(c)
+
+			var p2Back []distanceOrder
+			_, _, err = New(&cfg, WithBefore(*c.Before)).Paginate(stmt, &p2Back)
+			s.Nil(err)
+			s.assertDistanceIDs(p2Back, 2, 6, 1)
+		}
+	}
+}
+
+func (s *paginatorSuite) TestPaginateInvalidSQLVars() {
+	var orders []distanceOrder
+	_, _, err := New(
+		WithRules(Rule{Key: "Distance", SQLRepr: "ABS(orders.id - ?)", SQLVars: []interface{}{4, 5}}),
+	).Paginate(s.db, &orders)
+	s.Equal(ErrInvalidSQLVars, err)
+
+	_, _, err = New(
+		WithRules(Rule{Key: "ID", SQLVars: []interface{}{4}}),
+	).Paginate(s.db, &orders)
+	s.Equal(ErrInvalidSQLVars, err)
+}
+
+func (s *paginatorSuite) assertDistanceIDs(orders []distanceOrder, expectedIDs ...int) {
+	s.Len(orders, len(expectedIDs))
+	for i, o := range orders {
+		s.Equal(expectedIDs[i], o.ID)
+	}
+}
@@ -0,0 +1,143 @@
+package paginator
+
+import (
+	"context"
+	"time"
+)
+
+func (s *paginatorSuite) TestFollowerFollowsNewRows() {
+	s.givenOrders(3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	follower := NewFollower(WithLimit(2), WithOrder(ASC))
+	follower.SetInterval(10 * time.Millisecond)
+
+	var orders []order
+	var ids []int
+	var polls int
+
+	err := follower.Follow(s.db.WithContext(ctx), &orders, func(cursor string) error {
+		polls++
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+		if len(ids) == 3 {
+			s.givenOrders(2)
+		}
+		if len(ids) == 5 {
+			cancel()
+		}
+		return nil
+	})
+	s.Equal(context.Canceled, err)
+	s.Equal(intRange(1, 5), ids)
+	s.Equal(3, polls)
+
+	// cursor of follower points to the last row
+	var rest []order
+	_, _, _ = New(
+		WithOrder(ASC),
+		WithAfter(*follower.Cursor().After),
+	).Paginate(s.db, &rest)
+	s.Len(rest, 0)
+}
+
+func (s *paginatorSuite) TestFollowerDefaultOptions() {
+	s.givenOrders(3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// order of follower is ASC, even though paginator defaults to DESC
+	follower := NewFollower(WithLimit(2))
+	follower.SetInterval(10 * time.Millisecond)
+
+	var orders []order
+	var ids []int
+
+	err := follower.Follow(s.db.WithContext(ctx), &orders, func(cursor string) error {
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+		if len(ids) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	s.Equal(context.Canceled, err)
+	s.Equal(intRange(1, 3), ids)
+}
+
+func (s *paginatorSuite) TestFollowerKeepsCursorOnEmptyPoll() {
+	s.givenOrders(2)
+
+	var orders []order
+	_, c, _ := New(WithLimit(1), WithOrder(ASC)).Paginate(s.db, &orders)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	follower := NewFollower(WithOrder(ASC), WithAfter(*c.After))
+	follower.SetInterval(5 * time.Millisecond)
+
+	var ids []int
+	err := follower.Follow(s.db.WithContext(ctx), &orders, func(cursor string) error {
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+		return nil
+	})
+	// deadline may be exceeded while waiting or polling
+	s.Error(err)
+	s.Equal([]int{2}, ids)
+	s.NotNil(follower.Cursor().After)
+
+	s.givenOrders(1)
+
+	var rest []order
+	_, _, _ = New(
+		WithOrder(ASC),
+		WithAfter(*follower.Cursor().After),
+	).Paginate(s.db, &rest)
+	s.assertIDs(rest, 3)
+}
+
+func (s *paginatorSuite) TestFollowerFollowChan() {
+	s.givenOrders(3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	follower := NewFollower(WithLimit(2), WithOrder(ASC))
+	follower.SetInterval(time.Hour)
+
+	var orders []order
+	rowsCh, errCh := follower.FollowChan(s.db.WithContext(ctx), &orders)
+
+	p1 := <-rowsCh
+	s.assertIDs(*p1.(*[]order), 1, 2)
+
+	p2 := <-rowsCh
+	s.assertIDs(*p2.(*[]order), 3)
+
+	// previous pages are not overwritten by later polls
+	s.assertIDs(*p1.(*[]order), 1, 2)
+
+	cancel()
+	s.Equal(context.Canceled, <-errCh)
+}
+
+func (s *paginatorSuite) TestFollowerInvalidDest() {
+	var o order
+	err := NewFollower().Follow(s.db, &o, func(cursor string) error {
+		return nil
+	})
+	s.Equal(ErrInvalidDest, err)
+
+	err = NewFollower().Follow(s.db, nil, func(cursor string) error {
+		return nil
+	})
+	s.Equal(ErrInvalidDest, err)
+}
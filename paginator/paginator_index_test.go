@@ -0,0 +1,184 @@
+package paginator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pilagod/pointer"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+func TestAdviseIndex(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		dialector fakeDialector
+		opts      []Option
+		want      string
+	}{
+		{
+			dialector: fakeDialector{name: "postgres"},
+			opts:      []Option{WithKeys("CreatedAt"), WithTiebreaker(TiebreakerAppend)},
+			want:      "CREATE INDEX idx_orders_created_at_id ON orders (created_at DESC, id DESC)",
+		},
+		{
+			dialector: fakeDialector{name: "postgres"},
+			opts: []Option{WithRules(
+				Rule{Key: "Remark", NULLReplacement: "", SQLType: pointer.String("varchar")},
+				Rule{Key: "ID"},
+			), WithOrder(ASC)},
+			want: "CREATE INDEX idx_orders_remark_id ON orders " +
+				"((CAST( COALESCE(remark, CAST('' as varchar)) AS varchar )) ASC, id ASC)",
+		},
+		{
+			dialector: fakeDialector{name: "postgres"},
+			opts: []Option{WithRules(
+				Rule{Key: "Remark", Order: ASC, NullsOrder: NullsLast},
+				Rule{Key: "ID", Order: DESC},
+			)},
+			want: "CREATE INDEX idx_orders_remark_id ON orders (remark ASC NULLS LAST, id DESC)",
+		},
+		{
+			dialector: fakeDialector{name: "postgres"},
+			opts: []Option{WithRules(
+				Rule{Key: "ID", SQLRepr: "ABS(id - ?)", SQLVars: []interface{}{4}},
+			), WithTiebreaker(TiebreakerNone)},
+			want: "CREATE INDEX idx_orders_id ON orders ((ABS(id - '4')) DESC)",
+		},
+		{
+			dialector: fakeDialector{name: "postgres"},
+			opts: []Option{WithRules(
+				Rule{Key: "Remark", SQLRepr: "similarity(remark, ?) + ?", SQLVars: []interface{}{"a?b", 1}},
+			), WithTiebreaker(TiebreakerNone)},
+			want: "CREATE INDEX idx_orders_remark ON orders ((similarity(remark, 'a?b') + '1') DESC)",
+		},
+		{
+			dialector: fakeDialector{name: "mysql"},
+			opts: []Option{WithRules(
+				Rule{Key: "Remark", Order: ASC, NullsOrder: NullsLast},
+				Rule{Key: "ID", Order: DESC},
+			)},
+			want: "CREATE INDEX idx_orders_remark_id ON orders (remark ASC, id DESC)",
+		},
+	}
+
+	for _, c := range tc {
+		db, err := gorm.Open(c.dialector, &gorm.Config{DryRun: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var orders []order
+		advice, err := New(c.opts...).AdviseIndex(db, &orders)
+		if assert.Nil(t, err) {
+			assert.Equal(t, c.want, advice.DDL)
+		}
+	}
+}
+
+type indexedOrder struct {
+	ID        int       `gorm:"primaryKey;index:idx_orders_created_at_id,priority:2"`
+	CreatedAt time.Time `gorm:"index:idx_orders_created_at_id,priority:1"`
+}
+
+func (indexedOrder) TableName() string {
+	return "orders"
+}
+
+func (s *paginatorSuite) TestCheckIndex() {
+	var orders []indexedOrder
+
+	name, err := New(WithKeys("ID")).CheckIndex(s.db, &orders)
+	s.Nil(err)
+	s.Equal("PRIMARY KEY", name)
+
+	_, err = New(WithKeys("CreatedAt")).CheckIndex(s.db, &orders)
+	s.Equal(ErrNoCoveringIndex, err)
+
+	s.Require().Nil(s.db.Migrator().CreateIndex(&indexedOrder{}, "idx_orders_created_at_id"))
+	defer s.db.Migrator().DropIndex(&indexedOrder{}, "idx_orders_created_at_id")
+
+	// index can be scanned backward for rules in DESC order
+	name, err = New(WithKeys("CreatedAt")).CheckIndex(s.db, &orders)
+	s.Nil(err)
+	s.Equal("idx_orders_created_at_id", name)
+
+	_, err = New(WithRules(
+		Rule{Key: "CreatedAt", Order: ASC},
+		Rule{Key: "ID", Order: DESC},
+	)).CheckIndex(s.db, &orders)
+	s.Equal(ErrNoCoveringIndex, err)
+}
+
+func (s *paginatorSuite) TestCheckIndexNotDeclaredInModel() {
+	var orders []order
+
+	_, err := New(WithKeys("Remark", "ID")).CheckIndex(s.db, &orders)
+	s.Equal(ErrNoCoveringIndex, err)
+
+	// index created by migration rather than declared in model
+	s.Require().Nil(s.db.Exec("CREATE INDEX idx_orders_remark_id ON orders (remark DESC, id DESC)").Error)
+	defer s.db.Migrator().DropIndex(&order{}, "idx_orders_remark_id")
+
+	name, err := New(WithKeys("Remark", "ID")).CheckIndex(s.db, &orders)
+	s.Nil(err)
+	s.Equal("idx_orders_remark_id", name)
+
+	_, err = New(WithRules(
+		Rule{Key: "Remark", Order: ASC},
+		Rule{Key: "ID", Order: DESC},
+	)).CheckIndex(s.db, &orders)
+	s.Equal(ErrNoCoveringIndex, err)
+}
+
+func TestParseIndexDef(t *testing.T) {
+	t.Parallel()
+
+	column := func(name string, sort string) schema.IndexOption {
+		return schema.IndexOption{Field: &schema.Field{DBName: name}, Sort: sort}
+	}
+
+	tc := []struct {
+		def  string
+		want []schema.IndexOption
+	}{
+		{
+			def:  "CREATE INDEX idx ON public.orders USING btree (created_at DESC NULLS LAST, id)",
+			want: []schema.IndexOption{column("created_at", "DESC"), column("id", "")},
+		},
+		{
+			def:  "CREATE INDEX `idx` ON `orders`(`created_at`,`id` ASC)",
+			want: []schema.IndexOption{column("created_at", ""), column("id", "ASC")},
+		},
+		{
+			def: `CREATE INDEX "idx" ON "orders" ((COALESCE("remark", '')) DESC, "id" DESC) WHERE remark IS NOT NULL`,
+			want: []schema.IndexOption{
+				{Expression: `(COALESCE("remark", ''))`, Sort: "DESC"},
+				column("id", "DESC"),
+			},
+		},
+		{
+			def:  "",
+			want: nil,
+		},
+	}
+
+	for _, c := range tc {
+		assert.Equal(t, c.want, parseIndexDef(c.def), c.def)
+	}
+}
+
+func (s *paginatorSuite) TestAdviseIndexDDL() {
+	var orders []order
+	advice, err := New(WithRules(
+		Rule{Key: "Remark", NULLReplacement: ""},
+		Rule{Key: "CreatedAt", Order: ASC},
+	), WithTiebreaker(TiebreakerAppend)).AdviseIndex(s.db, &orders)
+	s.Require().Nil(err)
+	s.Equal("idx_orders_remark_created_at_id", advice.Name)
+
+	s.Require().Nil(s.db.Exec(advice.DDL).Error)
+	defer s.db.Migrator().DropIndex(&order{}, advice.Name)
+	s.True(s.db.Migrator().HasIndex(&order{}, advice.Name))
+}
@@ -0,0 +1,104 @@
+package paginator
+
+import (
+	"fmt"
+	"time"
+)
+
+func (s *paginatorSuite) TestGroupedCursorQueryEquivalence() {
+	// small value sets so that rows share values on leading rules
+	base := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var orders []order
+	for i := 0; i < 12; i++ {
+		orders = append(orders, order{
+			ID:        i + 1,
+			Remark:    ptrStr(fmt.Sprintf("r%d", i%3)),
+			CreatedAt: base.Add(time.Duration(i%2) * time.Hour),
+		})
+	}
+	s.givenOrders(orders)
+
+	orderSets := [][]Order{
+		{ASC, ASC, DESC},
+		{ASC, DESC, ASC},
+		{ASC, DESC, DESC},
+		{DESC, ASC, ASC},
+		{DESC, ASC, DESC},
+		{DESC, DESC, ASC},
+	}
+	for _, orderSet := range orderSets {
+		for _, tupleCmp := range []bool{false, true} {
+			for _, backward := range []bool{false, true} {
+				p := New(WithRules(
+					Rule{Key: "Remark", Order: orderSet[0]},
+					Rule{Key: "CreatedAt", Order: orderSet[1]},
+					Rule{Key: "ID", Order: orderSet[2]},
+				))
+				var dest []order
+				s.Require().Nil(p.validate(s.db, &dest))
+				s.Require().Nil(p.setup(s.db, &dest))
+				p.tupleCmp = tupleCmp
+
+				for _, o := range orders {
+					fields := []interface{}{*o.Remark, o.CreatedAt, o.ID}
+					expanded := s.queryIDs(p.buildCursorSQLQuery(fields, backward))
+					grouped := s.queryIDs(p.buildGroupedCursorSQLQuery(fields, backward))
+					s.Equal(
+						expanded,
+						grouped,
+						"orders: %v, tuple: %v, backward: %v, cursor: %d", orderSet, tupleCmp, backward, o.ID,
+					)
+				}
+			}
+		}
+	}
+}
+
+func (s *paginatorSuite) TestPaginateMixedOrders() {
+	base := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.givenOrders([]order{
+		{ID: 1, CreatedAt: base},
+		{ID: 2, CreatedAt: base},
+		{ID: 3, CreatedAt: base.Add(time.Hour)},
+		{ID: 4, CreatedAt: base.Add(time.Hour)},
+		{ID: 5, CreatedAt: base.Add(time.Hour)},
+	})
+
+	cfg := Config{
+		Rules: []Rule{
+			{Key: "CreatedAt", Order: ASC},
+			{Key: "ID", Order: DESC},
+		},
+		Limit:         2,
+		AllowTupleCmp: TRUE,
+	}
+
+	var p1 []order
+	_, c, _ := New(&cfg).Paginate(s.db, &p1)
+	s.assertIDs(p1, 2, 1)
+	s.assertForwardOnly(c)
+
+	var p2 []order
+	_, c, _ = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.assertIDs(p2, 5, 4)
+	s.assertBothDirections(c)
+
+	var p3 []order
+	_, c, _ = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p3)
+	s.assertIDs(p3, 3)
+	s.assertBackwardOnly(c)
+
+	var p4 []order
+	_, c, _ = New(&cfg, WithBefore(*c.Before)).Paginate(s.db, &p4)
+	s.assertIDs(p4, 5, 4)
+	s.assertBothDirections(c)
+}
+
+func (s *paginatorSuite) queryIDs(query string, args []interface{}) (ids []int) {
+	var orders []order
+	s.Require().Nil(s.db.Where(query, args...).Order("id").Find(&orders).Error)
+	for _, o := range orders {
+		ids = append(ids, o.ID)
+	}
+	return
+}
@@ -0,0 +1,121 @@
+package paginator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildQueryMapDest(t *testing.T) {
+	t.Parallel()
+
+	after := encodeCursor(t, []string{"created_at", "id"}, map[string]interface{}{"id": 2, "created_at": "2021-06-01"})
+
+	db := dryRunDB(t)
+
+	var rows []map[string]interface{}
+	q, err := New(
+		WithKeys("created_at", "id"),
+		WithAfter(after),
+		WithAllowTupleCmp(TRUE),
+	).BuildQuery(db.Table("orders"), &rows)
+	if assert.Nil(t, err) {
+		assert.Equal(
+			t,
+			"SELECT * FROM orders WHERE (orders.created_at, orders.id) < (?,?) "+
+				"ORDER BY orders.created_at DESC, orders.id DESC LIMIT 11",
+			q.SQL,
+		)
+		assert.Equal(t, []interface{}{"2021-06-01", int64(2)}, q.Vars)
+	}
+
+	// keys are resolved on model when there is one, and primary key is appended as tiebreaker
+	q, err = New(WithKeys("CreatedAt"), WithTiebreaker(TiebreakerAppend)).BuildQuery(db.Model(&order{}), &rows)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "SELECT * FROM orders ORDER BY orders.created_at DESC, orders.id DESC LIMIT 11", q.SQL)
+	}
+
+	// table-only query has no model to derive rules from
+	_, err = New().BuildQuery(db.Table("orders"), &rows)
+	assert.Equal(t, ErrInvalidModel, err)
+
+	// map dest needs table or model to qualify columns by
+	_, err = New(WithKeys("id")).BuildQuery(db, &rows)
+	assert.Equal(t, ErrInvalidModel, err)
+}
+
+func (s *paginatorSuite) TestPaginateMapDest() {
+	now := time.Now()
+	s.givenOrders([]order{
+		{ID: 1, CreatedAt: now.Add(1 * time.Hour)},
+		{ID: 2, CreatedAt: now.Add(3 * time.Hour)},
+		{ID: 3, CreatedAt: now.Add(2 * time.Hour)},
+	})
+
+	cfg := Config{
+		Rules: []Rule{
+			{Key: "created_at", ValueType: reflect.TypeOf(time.Time{})},
+			{Key: "id"},
+		},
+		Limit: 2,
+	}
+
+	var p1 []map[string]interface{}
+	_, c, err := New(&cfg).Paginate(s.db.Table("orders"), &p1)
+	s.Nil(err)
+	s.assertMapIDs(p1, 2, 3)
+	s.assertForwardOnly(c)
+
+	var p2 []map[string]interface{}
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db.Table("orders"), &p2)
+	s.Nil(err)
+	s.assertMapIDs(p2, 1)
+	s.assertBackwardOnly(c)
+
+	var p1Back []map[string]interface{}
+	_, _, err = New(&cfg, WithBefore(*c.Before)).Paginate(s.db.Table("orders"), &p1Back)
+	s.Nil(err)
+	s.assertMapIDs(p1Back, 2, 3)
+}
+
+func (s *paginatorSuite) TestPaginateMapDestInferredType() {
+	s.givenOrders(5)
+
+	cfg := Config{
+		Keys:  []string{"id"},
+		Limit: 2,
+		Order: ASC,
+	}
+
+	var p1 []map[string]interface{}
+	_, c, err := New(&cfg).Paginate(s.db.Table("orders"), &p1)
+	s.Nil(err)
+	s.assertMapIDs(p1, 1, 2)
+
+	var p2 []map[string]interface{}
+	_, _, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db.Table("orders"), &p2)
+	s.Nil(err)
+	s.assertMapIDs(p2, 3, 4)
+}
+
+func (s *paginatorSuite) TestPaginateMapDestNarrowSelect() {
+	s.givenOrders(2)
+
+	var rows []map[string]interface{}
+	_, c, err := New(WithKeys("created_at", "id"), WithLimit(1)).
+		Paginate(s.db.Table("orders").Select("remark"), &rows)
+	s.Nil(err)
+	s.Len(rows, 1)
+	s.Contains(rows[0], "created_at")
+	s.Contains(rows[0], "id")
+	s.NotNil(c.After)
+}
+
+func (s *paginatorSuite) assertMapIDs(rows []map[string]interface{}, ids ...int) {
+	s.Len(rows, len(ids))
+	for i, row := range rows {
+		s.EqualValues(ids[i], row["id"])
+	}
+}
@@ -0,0 +1,93 @@
+package paginator
+
+func (s *paginatorSuite) TestUpdateInBatches() {
+	s.givenOrders(25)
+
+	var orders []order
+	var progresses []BatchProgress
+
+	rowsAffected, err := New(
+		WithLimit(10),
+		WithOrder(ASC),
+	).UpdateInBatches(
+		s.db.Where("id > ?", 3),
+		&orders,
+		map[string]interface{}{"remark": "updated"},
+		func(p BatchProgress) {
+			s.Len(orders, int(p.RowsAffected))
+			progresses = append(progresses, p)
+		},
+	)
+	s.Nil(err)
+	s.Equal(int64(22), rowsAffected)
+
+	s.Len(progresses, 3)
+	s.Equal(int64(10), progresses[0].RowsAffected)
+	s.Equal(int64(20), progresses[1].TotalRowsAffected)
+	s.Equal(int64(2), progresses[2].RowsAffected)
+	s.Equal(int64(22), progresses[2].TotalRowsAffected)
+
+	var updated []order
+	s.db.Where("remark = ?", "updated").Order("id").Find(&updated)
+	s.assertIDRange(updated, 4, 25)
+
+	// cursor of progress can be used to resume
+	var p1 []order
+	_, _, _ = New(
+		WithLimit(10),
+		WithOrder(ASC),
+		WithAfter(progresses[0].Cursor),
+	).Paginate(s.db, &p1)
+	s.assertIDRange(p1, 14, 23)
+}
+
+func (s *paginatorSuite) TestUpdateInBatchesShouldNotChangePagingKeys() {
+	var orders []order
+	_, err := New(
+		WithKeys("CreatedAt", "ID"),
+	).UpdateInBatches(s.db, &orders, map[string]interface{}{"created_at": nil}, nil)
+	s.Equal(ErrUpdatePagingKey, err)
+}
+
+func (s *paginatorSuite) TestDeleteInBatches() {
+	s.givenOrders(25)
+
+	var orders []order
+	var batches int
+
+	rowsAffected, err := New(
+		WithLimit(10),
+		WithOrder(DESC),
+	).DeleteInBatches(
+		s.db.Where("id <= ?", 21),
+		&orders,
+		func(p BatchProgress) {
+			batches = p.Batch
+		},
+	)
+	s.Nil(err)
+	s.Equal(int64(21), rowsAffected)
+	s.Equal(3, batches)
+
+	var rest []order
+	s.db.Order("id").Find(&rest)
+	s.assertIDRange(rest, 22, 25)
+}
+
+func (s *paginatorSuite) TestDeleteInBatchesNoRows() {
+	var orders []order
+	rowsAffected, err := New().DeleteInBatches(s.db, &orders, func(p BatchProgress) {
+		s.Fail("no batch should be reported")
+	})
+	s.Nil(err)
+	s.Equal(int64(0), rowsAffected)
+}
+
+func (s *paginatorSuite) TestDeleteInBatchesInvalidDest() {
+	var o order
+	_, err := New().DeleteInBatches(s.db, &o, nil)
+	s.Equal(ErrInvalidDest, err)
+
+	_, err = New().DeleteInBatches(s.db, nil, nil)
+	s.Equal(ErrInvalidDest, err)
+}
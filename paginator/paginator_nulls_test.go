@@ -0,0 +1,146 @@
+package paginator
+
+func (s *paginatorSuite) TestPaginateNullsLast() {
+	s.givenOrders([]order{
+		{ID: 1, Remark: ptrStr("r1")},
+		{ID: 2, Remark: nil},
+		{ID: 3, Remark: ptrStr("r3")},
+		{ID: 4, Remark: nil},
+		{ID: 5, Remark: ptrStr("r5")},
+	})
+
+	cfg := Config{
+		Rules: []Rule{
+			{
+				Key:        "Remark",
+				NullsOrder: NullsLast,
+			},
+			{
+				Key: "ID",
+			},
+		},
+		Limit: 2,
+		Order: ASC,
+	}
+
+	var p1 []order
+	_, c, _ := New(&cfg).Paginate(s.db, &p1)
+	s.assertIDs(p1, 1, 3)
+	s.assertForwardOnly(c)
+
+	var p2 []order
+	_, c, _ = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.assertIDs(p2, 5, 2)
+	s.assertBothDirections(c)
+
+	var p3 []order
+	_, c, _ = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p3)
+	s.assertIDs(p3, 4)
+	s.assertBackwardOnly(c)
+
+	var p4 []order
+	_, c, _ = New(&cfg, WithBefore(*c.Before)).Paginate(s.db, &p4)
+	s.assertIDs(p4, 5, 2)
+	s.assertBothDirections(c)
+
+	var p5 []order
+	_, c, _ = New(&cfg, WithBefore(*c.Before)).Paginate(s.db, &p5)
+	s.assertIDs(p5, 1, 3)
+	s.assertForwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateNullsFirst() {
+	s.givenOrders([]order{
+		{ID: 1, Remark: ptrStr("r1")},
+		{ID: 2, Remark: nil},
+		{ID: 3, Remark: ptrStr("r3")},
+		{ID: 4, Remark: nil},
+		{ID: 5, Remark: ptrStr("r5")},
+	})
+
+	cfg := Config{
+		Rules: []Rule{
+			{
+				Key:        "Remark",
+				NullsOrder: NullsFirst,
+			},
+			{
+				Key: "ID",
+			},
+		},
+		Limit: 2,
+		Order: DESC,
+	}
+
+	var p1 []order
+	_, c, _ := New(&cfg).Paginate(s.db, &p1)
+	s.assertIDs(p1, 4, 2)
+	s.assertForwardOnly(c)
+
+	var p2 []order
+	_, c, _ = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p2)
+	s.assertIDs(p2, 5, 3)
+	s.assertBothDirections(c)
+
+	var p3 []order
+	_, c, _ = New(&cfg, WithAfter(*c.After)).Paginate(s.db, &p3)
+	s.assertIDs(p3, 1)
+	s.assertBackwardOnly(c)
+
+	var p4 []order
+	_, c, _ = New(&cfg, WithBefore(*c.Before)).Paginate(s.db, &p4)
+	s.assertIDs(p4, 5, 3)
+	s.assertBothDirections(c)
+
+	var p5 []order
+	_, c, _ = New(&cfg, WithBefore(*c.Before)).Paginate(s.db, &p5)
+	s.assertIDs(p5, 4, 2)
+	s.assertForwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateNullsOrderWithTupleCmp() {
+	s.givenOrders([]order{
+		{ID: 1, Remark: nil},
+		{ID: 2, Remark: ptrStr("r2")},
+		{ID: 3, Remark: nil},
+	})
+
+	cfg := Config{
+		Rules: []Rule{
+			{
+				Key:        "Remark",
+				NullsOrder: NullsLast,
+			},
+			{
+				Key: "ID",
+			},
+		},
+		Limit:         1,
+		Order:         ASC,
+		AllowTupleCmp: TRUE,
+	}
+
+	var ids []int
+	var c Cursor
+	for i := 0; i < 3; i++ {
+		var orders []order
+		opts := []Option{&cfg}
+		if c.After != nil {
+			opts = append(opts, WithAfter(*c.After))
+		}
+		_, c, _ = New(opts...).Paginate(s.db, &orders)
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+	}
+	s.Equal([]int{2, 1, 3}, ids)
+	s.assertBackwardOnly(c)
+}
+
+func (s *paginatorSuite) TestPaginateInvalidNullsOrder() {
+	var orders []order
+	_, _, err := New(
+		WithRules(Rule{Key: "Remark", NullsOrder: "NULLS MIDDLE"}),
+	).Paginate(s.db, &orders)
+	s.Equal(ErrInvalidNullsOrder, err)
+}
@@ -0,0 +1,181 @@
+package paginator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+func (s *paginatorSuite) TestParallelScannerPlanRange() {
+	s.givenOrders(100)
+
+	var orders []order
+	partitions, err := NewParallelScanner(
+		"orders",
+		NewMemoryCheckpointStore(),
+	).Plan(s.db, &orders, 4)
+	s.Nil(err)
+
+	s.Len(partitions, 4)
+	s.Nil(partitions[0].From)
+	s.EqualValues(25, partitions[0].To)
+	s.EqualValues(25, partitions[1].From)
+	s.EqualValues(50, partitions[1].To)
+	s.EqualValues(75, partitions[3].From)
+	s.Nil(partitions[3].To)
+}
+
+func (s *paginatorSuite) TestParallelScannerPlanQuantile() {
+	s.givenOrders([]order{
+		{ID: 1, Remark: ptrStr("a")},
+		{ID: 2, Remark: ptrStr("b")},
+		{ID: 3, Remark: ptrStr("c")},
+		{ID: 4, Remark: ptrStr("d")},
+		{ID: 5, Remark: nil},
+	})
+
+	var orders []order
+	scanner := NewParallelScanner(
+		"orders",
+		NewMemoryCheckpointStore(),
+		WithKeys("Remark"),
+	)
+	// remark is neither numeric nor time, falls back to quantile
+	partitions, err := scanner.Plan(s.db, &orders, 2)
+	s.Nil(err)
+
+	s.Len(partitions, 2)
+	s.Nil(partitions[0].From)
+	s.Equal("c", fmt.Sprintf("%s", partitions[0].To))
+	s.Equal("c", fmt.Sprintf("%s", partitions[1].From))
+	s.Nil(partitions[1].To)
+
+	// rows with NULL key are scanned by the first partition
+	ids, err := s.scanPartitions(scanner, partitions, nil)
+	s.Nil(err)
+	s.Equal([]int{1, 2, 3, 4, 5}, ids)
+}
+
+func (s *paginatorSuite) TestParallelScannerPlanQuantileNarrowSelect() {
+	s.givenOrders([]order{
+		{ID: 1, Remark: ptrStr("a")},
+		{ID: 2},
+		{ID: 3},
+		{ID: 4},
+	})
+
+	var orders []order
+	scanner := NewParallelScanner(
+		"orders",
+		NewMemoryCheckpointStore(),
+		WithKeys("ID"),
+	)
+	scanner.SetStrategy(QUANTILE)
+	// rows are counted even when selected columns are NULL
+	partitions, err := scanner.Plan(s.db.Select("remark"), &orders, 2)
+	s.Nil(err)
+
+	s.Len(partitions, 2)
+	s.EqualValues(3, partitions[0].To)
+}
+
+func (s *paginatorSuite) TestParallelScannerPlanInvalidPartitions() {
+	var orders []order
+	_, err := NewParallelScanner(
+		"orders",
+		NewMemoryCheckpointStore(),
+	).Plan(s.db, &orders, 0)
+	s.Equal(ErrInvalidPartitions, err)
+}
+
+func (s *paginatorSuite) TestParallelScannerRunInvalidDest() {
+	scanner := NewParallelScanner("orders", NewMemoryCheckpointStore())
+	handler := func(tx *gorm.DB, partition Partition, rows interface{}) error {
+		return nil
+	}
+	var o order
+	s.Equal(ErrInvalidDest, scanner.Run(s.db, &o, []Partition{{}}, handler))
+	s.Equal(ErrInvalidDest, scanner.Run(s.db, nil, []Partition{{}}, handler))
+}
+
+func (s *paginatorSuite) TestParallelScannerRun() {
+	s.givenOrders(100)
+
+	var orders []order
+	scanner := NewParallelScanner(
+		"orders",
+		NewMemoryCheckpointStore(),
+		WithLimit(7),
+		WithOrder(ASC),
+	)
+	scanner.SetWorkers(3)
+
+	partitions, err := scanner.Plan(s.db, &orders, 5)
+	s.Nil(err)
+
+	ids, err := s.scanPartitions(scanner, partitions, nil)
+	s.Nil(err)
+	s.Equal(intRange(1, 100), ids)
+}
+
+func (s *paginatorSuite) TestParallelScannerRetryFailedPartition() {
+	s.givenOrders(100)
+
+	var orders []order
+	scanner := NewParallelScanner(
+		"orders",
+		NewMemoryCheckpointStore(),
+		WithLimit(10),
+		WithOrder(ASC),
+	)
+	scanner.SetWorkers(2)
+
+	partitions, err := scanner.Plan(s.db, &orders, 4)
+	s.Nil(err)
+
+	errStop := errors.New("stop")
+	failOnce := true
+	ids, err := s.scanPartitions(scanner, partitions, func(partition Partition, batch []order) error {
+		if partition.Index == 2 && batch[0].ID >= 60 && failOnce {
+			failOnce = false
+			return errStop
+		}
+		return nil
+	})
+	// partition 2 covers [50, 75), it fails at its second batch
+	s.Equal(&PartitionError{Errors: map[int]error{2: errStop}}, err)
+	s.Equal(append(intRange(1, 59), intRange(75, 100)...), ids)
+
+	ids, err = s.scanPartitions(scanner, partitions[2:3], nil)
+	s.Nil(err)
+	s.Equal(intRange(60, 74), ids)
+}
+
+func (s *paginatorSuite) scanPartitions(
+	scanner *ParallelScanner,
+	partitions []Partition,
+	check func(partition Partition, batch []order) error,
+) ([]int, error) {
+	var mu sync.Mutex
+	var ids []int
+	var orders []order
+	err := scanner.Run(s.db, &orders, partitions, func(tx *gorm.DB, partition Partition, rows interface{}) error {
+		batch := *rows.(*[]order)
+		if check != nil {
+			if err := check(partition, batch); err != nil {
+				return err
+			}
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, o := range batch {
+			ids = append(ids, o.ID)
+		}
+		return nil
+	})
+	sort.Ints(ids)
+	return ids, err
+}
@@ -0,0 +1,111 @@
+package paginator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pilagod/pointer"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestBuildQuery(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	c := encodeCursor(t, []string{"CreatedAt", "ID"}, order{ID: 2, CreatedAt: createdAt})
+
+	tc := []struct {
+		opts       []Option
+		wantSQL    string
+		wantVars   []interface{}
+		wantValues []interface{}
+	}{
+		{
+			opts:    []Option{WithKeys("CreatedAt", "ID"), WithLimit(3)},
+			wantSQL: "SELECT * FROM orders ORDER BY orders.created_at DESC, orders.id DESC LIMIT 4",
+		},
+		{
+			opts: []Option{WithKeys("CreatedAt", "ID"), WithLimit(3), WithAfter(c)},
+			wantSQL: "SELECT * FROM orders WHERE (orders.created_at, orders.id) < (?,?) " +
+				"ORDER BY orders.created_at DESC, orders.id DESC LIMIT 4",
+			wantVars:   []interface{}{createdAt, 2},
+			wantValues: []interface{}{createdAt, 2},
+		},
+		{
+			opts: []Option{WithKeys("CreatedAt", "ID"), WithLimit(3), WithBefore(c)},
+			wantSQL: "SELECT * FROM orders WHERE (orders.created_at, orders.id) > (?,?) " +
+				"ORDER BY orders.created_at ASC, orders.id ASC LIMIT 4",
+			wantVars:   []interface{}{createdAt, 2},
+			wantValues: []interface{}{createdAt, 2},
+		},
+	}
+
+	db := dryRunDB(t)
+	for _, c := range tc {
+		var orders []order
+		q, err := New(append(c.opts, WithAllowTupleCmp(AUTO))...).BuildQuery(db, &orders)
+		if assert.Nil(t, err) {
+			assert.Equal(t, c.wantSQL, q.SQL)
+			assert.Equal(t, c.wantVars, q.Vars)
+			assert.Equal(t, c.wantValues, q.CursorValues)
+		}
+	}
+}
+
+func TestBuildQueryReplacesNULLCursorValues(t *testing.T) {
+	t.Parallel()
+
+	c := encodeCursor(t, []string{"Remark", "ID"}, order{ID: 2})
+
+	db := dryRunDB(t)
+	var orders []order
+	q, err := New(
+		WithRules(
+			Rule{Key: "Remark", NULLReplacement: "", SQLType: pointer.String("varchar")},
+			Rule{Key: "ID"},
+		),
+		WithAfter(c),
+	).BuildQuery(db, &orders)
+	if assert.Nil(t, err) {
+		assert.Equal(t, []interface{}{"", 2}, q.CursorValues)
+	}
+}
+
+func TestBuildQueryInvalidCursor(t *testing.T) {
+	t.Parallel()
+
+	db := dryRunDB(t)
+	var orders []order
+	_, err := New(WithAfter("invalid cursor")).BuildQuery(db, &orders)
+	assert.Equal(t, ErrInvalidCursor, err)
+}
+
+func (s *paginatorSuite) TestBuildQueryRunsAsPaginate() {
+	s.givenOrders(5)
+
+	p := New(WithKeys("CreatedAt", "ID"), WithLimit(2))
+
+	var orders []order
+	q, err := p.BuildQuery(s.db, &orders)
+	s.Nil(err)
+	s.Nil(q.CursorValues)
+	s.Nil(q.DB.Find(&orders).Error)
+	// query fetches one more row to tell whether there are more rows
+	s.assertIDs(orders, 5, 4, 3)
+
+	// paginator is left untouched by BuildQuery
+	var p1 []order
+	_, c, err := p.Paginate(s.db, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 5, 4)
+	s.assertForwardOnly(c)
+}
+
+func (s *paginatorSuite) TestBuildQueryClauseConflict() {
+	var orders []order
+	_, err := New().BuildQuery(s.db.Scopes(func(db *gorm.DB) *gorm.DB {
+		return db.Limit(1)
+	}), &orders)
+	s.Equal(ErrClauseConflict, err)
+}
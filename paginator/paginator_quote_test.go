@@ -0,0 +1,46 @@
+package paginator
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type reservedWord struct {
+	ID    int    `gorm:"primaryKey"`
+	Order int    `gorm:"not null"`
+	Group string `gorm:"type:varchar(30);not null"`
+}
+
+func (s *paginatorSuite) TestPaginateQuoteIdentifiers() {
+	// mixed-case table name and reserved-word column names need quoting
+	db, err := gorm.Open(s.db.Dialector, &gorm.Config{
+		NamingStrategy: schema.NamingStrategy{TablePrefix: "Paginator_"},
+	})
+	s.Require().Nil(err)
+	s.Require().Nil(db.AutoMigrate(&reservedWord{}))
+	defer db.Migrator().DropTable(&reservedWord{})
+
+	s.Require().Nil(db.Create(&[]reservedWord{
+		{ID: 1, Order: 2, Group: "a"},
+		{ID: 2, Order: 1, Group: "b"},
+		{ID: 3, Order: 1, Group: "a"},
+	}).Error)
+
+	cfg := Config{
+		Keys:  []string{"Order", "Group", "ID"},
+		Limit: 2,
+		Order: ASC,
+	}
+
+	var p1 []reservedWord
+	_, c, err := New(&cfg).Paginate(db, &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 3, 2)
+	s.assertForwardOnly(c)
+
+	var p2 []reservedWord
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(db, &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 1)
+	s.assertBackwardOnly(c)
+}
@@ -0,0 +1,79 @@
+package paginator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// orderedItem belongs to order, which is joined by db.Joins("Order")
+type orderedItem struct {
+	ID      int `gorm:"primaryKey"`
+	OrderID int
+	Order   *order `gorm:"foreignKey:OrderID"`
+}
+
+func (orderedItem) TableName() string {
+	return "items"
+}
+
+func TestBuildQueryRelationKey(t *testing.T) {
+	t.Parallel()
+
+	db := dryRunDB(t)
+
+	var items []orderedItem
+	q, err := New(WithKeys("Order.CreatedAt", "ID")).BuildQuery(db.Joins("Order"), &items)
+	if assert.Nil(t, err) {
+		assert.True(t, strings.HasSuffix(q.SQL, "ORDER BY Order.created_at DESC, items.id DESC LIMIT 11"), q.SQL)
+	}
+
+	q, err = New(WithKeys("Order.CreatedAt", "ID"), WithDerivedTable("t")).BuildQuery(db.Joins("Order"), &items)
+	if assert.Nil(t, err) {
+		assert.True(t, strings.HasSuffix(q.SQL, "ORDER BY t.Order__created_at DESC, t.id DESC LIMIT 11"), q.SQL)
+	}
+}
+
+func (s *paginatorSuite) TestPaginateRelationKey() {
+	orders := s.givenOrders(3)
+	s.givenItems(orders[2], 1)
+	s.givenItems(orders[0], 2)
+	s.givenItems(orders[1], 2)
+
+	cfg := Config{
+		Keys:  []string{"Order.CreatedAt", "ID"},
+		Limit: 2,
+	}
+
+	var p1 []orderedItem
+	_, c, err := New(&cfg).Paginate(s.db.Joins("Order"), &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 1, 5)
+	s.assertForwardOnly(c)
+
+	var p2 []orderedItem
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db.Joins("Order"), &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 4, 3)
+	s.assertBothDirections(c)
+
+	var p3 []orderedItem
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db.Joins("Order"), &p3)
+	s.Nil(err)
+	s.assertIDs(p3, 2)
+	s.assertBackwardOnly(c)
+
+	var p2Back []orderedItem
+	_, _, err = New(&cfg, WithBefore(*c.Before)).Paginate(s.db.Joins("Order"), &p2Back)
+	s.Nil(err)
+	s.assertIDs(p2Back, 4, 3)
+}
+
+func (s *paginatorSuite) TestPaginateInvalidRelationKey() {
+	var items []orderedItem
+	for _, key := range []string{"Order.Unknown", "Unknown.ID", "Order.CreatedAt.Unknown"} {
+		_, _, err := New(WithKeys(key)).Paginate(s.db.Joins("Order"), &items)
+		s.Equal(ErrInvalidModel, err, key)
+	}
+}
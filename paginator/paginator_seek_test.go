@@ -0,0 +1,90 @@
+package paginator
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (s *paginatorSuite) TestPaginateUnionAllStrategy() {
+	base := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var orders []order
+	for i := 0; i < 10; i++ {
+		var remark *string
+		if i%4 != 0 {
+			remark = ptrStr(fmt.Sprintf("r%d", i%3))
+		}
+		orders = append(orders, order{
+			ID:        i + 1,
+			Remark:    remark,
+			CreatedAt: base.Add(time.Duration(i%2) * time.Hour),
+		})
+	}
+	s.givenOrders(orders)
+
+	cfgs := []Config{
+		{
+			Keys:  []string{"ID"},
+			Limit: 3,
+		},
+		{
+			Rules: []Rule{
+				{Key: "CreatedAt", Order: ASC},
+				{Key: "ID", Order: DESC},
+			},
+			Limit: 3,
+		},
+		{
+			Rules: []Rule{
+				{Key: "Remark", NullsOrder: NullsLast},
+				{Key: "CreatedAt"},
+				{Key: "ID"},
+			},
+			Limit: 4,
+			Order: ASC,
+		},
+	}
+	for i, cfg := range cfgs {
+		db := s.db.Where("id <> ?", 5)
+		expected := s.paginateAll(db, cfg)
+		cfg.QueryStrategy = QueryStrategyUnionAll
+		s.Equal(expected, s.paginateAll(db, cfg), "config: %d", i)
+	}
+}
+
+func (s *paginatorSuite) TestPaginateInvalidQueryStrategy() {
+	var orders []order
+	_, _, err := New(
+		WithQueryStrategy("INDEX_SKIP"),
+	).Paginate(s.db, &orders)
+	s.Equal(ErrInvalidQueryStrategy, err)
+}
+
+type pageResult struct {
+	IDs    []int
+	Cursor Cursor
+}
+
+// paginateAll paginates forward to the end, and then backward to the beginning
+func (s *paginatorSuite) paginateAll(db *gorm.DB, cfg Config) (pages []pageResult) {
+	page := func(opts ...Option) Cursor {
+		var orders []order
+		_, c, err := New(append([]Option{&cfg}, opts...)...).Paginate(db, &orders)
+		s.Require().Nil(err)
+		var ids []int
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+		pages = append(pages, pageResult{IDs: ids, Cursor: c})
+		return c
+	}
+	c := page()
+	for c.After != nil {
+		c = page(WithAfter(*c.After))
+	}
+	for c.Before != nil {
+		c = page(WithBefore(*c.Before))
+	}
+	return
+}
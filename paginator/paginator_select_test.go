@@ -0,0 +1,136 @@
+package paginator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestBuildQueryNarrowSelect(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		name    string
+		query   func(db *gorm.DB) *gorm.DB
+		wantSQL string
+	}{
+		{
+			name:    "select columns",
+			query:   func(db *gorm.DB) *gorm.DB { return db.Select("id, remark") },
+			wantSQL: "SELECT id, remark,orders.created_at FROM orders ORDER BY orders.created_at DESC, orders.id DESC LIMIT 11",
+		},
+		{
+			name:    "select expression",
+			query:   func(db *gorm.DB) *gorm.DB { return db.Select("orders.id, ? AS tag", "x") },
+			wantSQL: "SELECT orders.id, ? AS tag, orders.created_at FROM orders ORDER BY orders.created_at DESC, orders.id DESC LIMIT 11",
+		},
+		{
+			name:    "select all columns",
+			query:   func(db *gorm.DB) *gorm.DB { return db.Select("orders.*, ? AS tag", "x") },
+			wantSQL: "SELECT orders.*, ? AS tag FROM orders ORDER BY orders.created_at DESC, orders.id DESC LIMIT 11",
+		},
+		{
+			name:    "select distinct columns",
+			query:   func(db *gorm.DB) *gorm.DB { return db.Select("DISTINCT id, created_at") },
+			wantSQL: "SELECT DISTINCT id, created_at FROM orders ORDER BY orders.created_at DESC, orders.id DESC LIMIT 11",
+		},
+		{
+			name:    "select distinct column",
+			query:   func(db *gorm.DB) *gorm.DB { return db.Select("DISTINCT id, remark") },
+			wantSQL: "SELECT DISTINCT id, remark,orders.created_at FROM orders ORDER BY orders.created_at DESC, orders.id DESC LIMIT 11",
+		},
+		{
+			name: "select aliases",
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Select("orders.id AS id, orders.created_at created_at")
+			},
+			wantSQL: "SELECT orders.id AS id, orders.created_at created_at FROM orders ORDER BY orders.created_at DESC, orders.id DESC LIMIT 11",
+		},
+	}
+
+	db := dryRunDB(t)
+	for _, c := range tc {
+		var orders []order
+		q, err := New(WithKeys("CreatedAt", "ID")).BuildQuery(c.query(db), &orders)
+		if assert.Nil(t, err, c.name) {
+			assert.Equal(t, c.wantSQL, q.SQL, c.name)
+		}
+	}
+}
+
+func TestBuildQueryNarrowSelectMissingExpression(t *testing.T) {
+	t.Parallel()
+
+	db := dryRunDB(t)
+	var orders []distanceOrder
+	_, err := New(
+		WithRules(
+			Rule{Key: "Distance", SQLRepr: "ABS(orders.id - ?)", SQLVars: []interface{}{4}},
+			Rule{Key: "ID"},
+		),
+	).BuildQuery(db.Select("id"), &orders)
+	assert.True(t, errors.Is(err, ErrMissingSelectColumn))
+	assert.Contains(t, err.Error(), "Distance")
+}
+
+func TestBuildQueryNarrowSelectMismatchedAlias(t *testing.T) {
+	t.Parallel()
+
+	db := dryRunDB(t)
+	var orders []order
+	_, err := New(WithKeys("CreatedAt", "ID")).BuildQuery(
+		db.Select("orders.id AS id, DATE_TRUNC('day', orders.created_at) AS created_at"),
+		&orders,
+	)
+	assert.True(t, errors.Is(err, ErrMissingSelectColumn))
+	assert.Contains(t, err.Error(), "CreatedAt")
+}
+
+func TestBuildQueryNarrowSelectKeepsQuery(t *testing.T) {
+	t.Parallel()
+
+	db := dryRunDB(t)
+	var orders []order
+
+	columns := db.Select("id, remark")
+	_, err := New(WithKeys("CreatedAt", "ID")).BuildQuery(columns, &orders)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"id, remark"}, columns.Statement.Selects)
+
+	expr := db.Select("orders.id, ? AS tag", "x")
+	_, err = New(WithKeys("CreatedAt", "ID")).BuildQuery(expr, &orders)
+	assert.Nil(t, err)
+	assert.Equal(t, "orders.id, ? AS tag", expr.Statement.Clauses["SELECT"].Expression.(clause.Expr).SQL)
+}
+
+func (s *paginatorSuite) TestPaginateNarrowSelect() {
+	now := time.Now()
+	s.givenOrders([]order{
+		{ID: 1, CreatedAt: now.Add(1 * time.Hour)},
+		{ID: 2, CreatedAt: now.Add(3 * time.Hour)},
+		{ID: 3, CreatedAt: now.Add(2 * time.Hour)},
+	})
+
+	cfg := Config{
+		Keys:  []string{"CreatedAt"},
+		Limit: 2,
+		// tiebreaker is selected as well
+		Tiebreaker: TiebreakerAppend,
+	}
+
+	var p1 []order
+	_, c, err := New(&cfg).Paginate(s.db.Select("remark"), &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 2, 3)
+	s.assertForwardOnly(c)
+
+	var p2 []order
+	_, c, err = New(&cfg, WithAfter(*c.After)).Paginate(s.db.Select("remark"), &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 1)
+	s.assertBackwardOnly(c)
+}
@@ -0,0 +1,109 @@
+package paginator
+
+import (
+	"time"
+)
+
+func (s *paginatorSuite) TestPaginateSnapshot() {
+	now := time.Now()
+	s.givenOrders([]order{
+		{ID: 1, CreatedAt: now},
+		{ID: 2, CreatedAt: now.Add(1 * time.Hour)},
+		{ID: 3, CreatedAt: now.Add(2 * time.Hour)},
+		{ID: 4, CreatedAt: now.Add(3 * time.Hour)},
+		{ID: 5, CreatedAt: now.Add(4 * time.Hour)},
+	})
+
+	cfg := Config{
+		Keys:        []string{"CreatedAt", "ID"},
+		Limit:       2,
+		SnapshotKey: "ID",
+	}
+
+	p := New(&cfg)
+	var p1 []order
+	_, c, _ := p.Paginate(s.db, &p1)
+	s.assertIDs(p1, 5, 4)
+	s.assertForwardOnly(c)
+	s.Equal(int64(0), p.SnapshotNewRows())
+
+	// order created during session would shift pages
+	s.givenOrders([]order{
+		{ID: 6, CreatedAt: now.Add(150 * time.Minute)},
+	})
+
+	p = New(&cfg, WithAfter(*c.After))
+	var p2 []order
+	_, c, _ = p.Paginate(s.db, &p2)
+	s.assertIDs(p2, 3, 2)
+	s.assertBothDirections(c)
+	s.Equal(int64(1), p.SnapshotNewRows())
+
+	p = New(&cfg, WithBefore(*c.Before))
+	var p1Again []order
+	_, _, _ = p.Paginate(s.db, &p1Again)
+	s.assertIDs(p1Again, 5, 4)
+	s.Equal(int64(1), p.SnapshotNewRows())
+
+	p = New(&cfg, WithAfter(*c.After))
+	var p3 []order
+	_, c, _ = p.Paginate(s.db, &p3)
+	s.assertIDs(p3, 1)
+	s.assertBackwardOnly(c)
+	s.Equal(int64(1), p.SnapshotNewRows())
+
+	// new session includes new order
+	var p1New []order
+	_, _, _ = New(&cfg, WithLimit(3)).Paginate(s.db, &p1New)
+	s.assertIDs(p1New, 5, 4, 6)
+}
+
+func (s *paginatorSuite) TestPaginateSnapshotNarrowSelect() {
+	s.givenOrders([]order{
+		{ID: 1, Remark: ptrStr("a")},
+		{ID: 2, Remark: ptrStr("b")},
+		{ID: 3, Remark: ptrStr("c")},
+	})
+
+	cfg := Config{
+		Keys:        []string{"ID"},
+		Limit:       2,
+		SnapshotKey: "ID",
+	}
+
+	var p1 []order
+	_, c, err := New(&cfg).Paginate(s.db.Select("id, remark"), &p1)
+	s.Nil(err)
+	s.assertIDs(p1, 3, 2)
+
+	// new rows are counted even when selected columns are NULL
+	s.givenOrders([]order{{ID: 4}, {ID: 5}})
+
+	p := New(&cfg, WithAfter(*c.After))
+	var p2 []order
+	_, _, err = p.Paginate(s.db.Select("remark"), &p2)
+	s.Nil(err)
+	s.assertIDs(p2, 1)
+	s.Equal(int64(2), p.SnapshotNewRows())
+}
+
+func (s *paginatorSuite) TestPaginateSnapshotNoRowsAtStart() {
+	cfg := Config{
+		Limit:       2,
+		SnapshotKey: "ID",
+	}
+
+	var p1 []order
+	_, c, err := New(&cfg).Paginate(s.db, &p1)
+	s.Nil(err)
+	s.Len(p1, 0)
+	s.assertNoMore(c)
+}
+
+func (s *paginatorSuite) TestPaginateSnapshotInvalidKey() {
+	var orders []order
+	_, _, err := New(
+		WithSnapshotKey("UnknownKey"),
+	).Paginate(s.db, &orders)
+	s.Equal(ErrInvalidModel, err)
+}
@@ -0,0 +1,135 @@
+package paginator
+
+import (
+	"time"
+)
+
+func (s *paginatorSuite) TestPaginateSync() {
+	s.givenOrders(5)
+
+	cfg := Config{
+		Keys:  []string{"CreatedAt", "ID"},
+		Limit: 2,
+		Order: ASC,
+		Sync:  TRUE,
+	}
+
+	p := New(&cfg)
+	var p1 []order
+	_, c, _ := p.Paginate(s.db, &p1)
+	s.assertIDs(p1, 1, 2)
+	s.assertForwardOnly(c)
+	s.Nil(p.SyncToken())
+
+	// order created during session is beyond high-water mark
+	s.givenOrders([]order{
+		{ID: 6, CreatedAt: time.Now().Add(100 * time.Hour)},
+	})
+
+	p = New(&cfg, WithAfter(*c.After))
+	var p2 []order
+	_, c, _ = p.Paginate(s.db, &p2)
+	s.assertIDs(p2, 3, 4)
+	s.assertBothDirections(c)
+	s.Nil(p.SyncToken())
+
+	// backward paging stays in the session
+	var p1Again []order
+	_, _, _ = New(&cfg, WithBefore(*c.Before)).Paginate(s.db, &p1Again)
+	s.assertIDs(p1Again, 1, 2)
+
+	p = New(&cfg, WithAfter(*c.After))
+	var p3 []order
+	_, c, _ = p.Paginate(s.db, &p3)
+	s.assertIDs(p3, 5)
+	s.assertBackwardOnly(c)
+	s.NotNil(p.SyncToken())
+
+	// next session starts from the sync token
+	p = New(&cfg, WithSyncToken(*p.SyncToken()))
+	var next []order
+	_, c, _ = p.Paginate(s.db, &next)
+	s.assertIDs(next, 6)
+	s.assertNoMore(c)
+	s.NotNil(p.SyncToken())
+
+	// nothing changed since last session, sync token is kept
+	token := *p.SyncToken()
+	p = New(&cfg, WithSyncToken(token))
+	var empty []order
+	_, c, _ = p.Paginate(s.db, &empty)
+	s.Len(empty, 0)
+	s.assertNoMore(c)
+	s.Equal(token, *p.SyncToken())
+}
+
+func (s *paginatorSuite) TestPaginateSyncRowUpdatedDuringSession() {
+	s.givenOrders(4)
+
+	cfg := Config{
+		Keys:  []string{"CreatedAt", "ID"},
+		Limit: 2,
+		Order: ASC,
+		Sync:  TRUE,
+	}
+
+	var p1 []order
+	_, c, _ := New(&cfg).Paginate(s.db, &p1)
+	s.assertIDs(p1, 1, 2)
+
+	// both a synced row and an unsynced row are updated during session
+	s.db.Model(&order{}).
+		Where("id IN ?", []int{1, 3}).
+		Update("created_at", time.Now().Add(100*time.Hour))
+
+	p := New(&cfg, WithAfter(*c.After))
+	var p2 []order
+	_, _, _ = p.Paginate(s.db, &p2)
+	s.assertIDs(p2, 4)
+
+	p = New(&cfg, WithSyncToken(*p.SyncToken()))
+	var next []order
+	_, _, _ = p.Paginate(s.db, &next)
+	s.assertIDs(next, 1, 3)
+}
+
+func (s *paginatorSuite) TestPaginateSyncNothingToSync() {
+	p := New(&Config{
+		Order: ASC,
+		Sync:  TRUE,
+	})
+	var orders []order
+	_, c, err := p.Paginate(s.db, &orders)
+	s.Nil(err)
+	s.assertNoMore(c)
+	s.Nil(p.SyncToken())
+
+	// rows created after session started are left to next session
+	s.givenOrders(1)
+
+	p = New(&Config{
+		Order: ASC,
+		Sync:  TRUE,
+	})
+	_, _, _ = p.Paginate(s.db, &orders)
+	s.assertIDs(orders, 1)
+	s.NotNil(p.SyncToken())
+}
+
+func (s *paginatorSuite) TestPaginateSyncInvalidOrder() {
+	var orders []order
+	_, _, err := New(
+		WithSync(TRUE),
+	).Paginate(s.db, &orders)
+	s.Equal(ErrInvalidSyncOrder, err)
+}
+
+func (s *paginatorSuite) TestPaginateSyncInvalidCursor() {
+	var orders []order
+	_, _, err := New(
+		WithOrder(ASC),
+		WithSync(TRUE),
+		WithAfter("invalid cursor"),
+	).Paginate(s.db, &orders)
+	s.Equal(ErrInvalidCursor, err)
+}
@@ -17,6 +17,8 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/stretchr/testify/suite"
+
+	"github.com/pilagod/gorm-cursor-paginator/v2/cursor"
 )
 
 func TestPaginator(t *testing.T) {
@@ -288,3 +290,32 @@ func (s *paginatorSuite) assertNoMore(c Cursor) {
 func ptrStr(v string) *string {
 	return &v
 }
+
+func intRange(from, to int) (result []int) {
+	for i := from; i <= to; i++ {
+		result = append(result, i)
+	}
+	return
+}
+
+// dryRunDB opens db generating PostgreSQL flavored SQL without database
+func dryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(fakeDialector{name: "postgres"}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// encodeCursor encodes values of keys on model into cursor
+func encodeCursor(t *testing.T, keys []string, model interface{}) string {
+	fields := make([]cursor.EncoderField, len(keys))
+	for i, key := range keys {
+		fields[i] = cursor.EncoderField{Key: key}
+	}
+	c, err := cursor.NewEncoder(fields).Encode(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
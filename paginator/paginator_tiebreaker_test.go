@@ -0,0 +1,126 @@
+package paginator
+
+import "time"
+
+func (s *paginatorSuite) TestPaginateAppendTiebreaker() {
+	createdAt := time.Now()
+	s.givenOrders([]order{
+		{ID: 1, CreatedAt: createdAt},
+		{ID: 2, CreatedAt: createdAt},
+		{ID: 3, CreatedAt: createdAt},
+		{ID: 4, CreatedAt: createdAt},
+		{ID: 5, CreatedAt: createdAt},
+	})
+
+	cfg := Config{
+		Keys:       []string{"CreatedAt"},
+		Limit:      2,
+		Order:      ASC,
+		Tiebreaker: TiebreakerAppend,
+	}
+
+	var ids []int
+	var c Cursor
+	for {
+		var orders []order
+		opts := []Option{&cfg}
+		if c.After != nil {
+			opts = append(opts, WithAfter(*c.After))
+		}
+		_, c, _ = New(opts...).Paginate(s.db, &orders)
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+		if c.After == nil {
+			break
+		}
+	}
+	s.Equal(intRange(1, 5), ids)
+}
+
+func (s *paginatorSuite) TestPaginateDefaultTiebreakerKeepsCursors() {
+	now := time.Now()
+	s.givenOrders([]order{
+		{ID: 1, CreatedAt: now},
+		{ID: 2, CreatedAt: now.Add(1 * time.Hour)},
+		{ID: 3, CreatedAt: now.Add(2 * time.Hour)},
+	})
+
+	// cursor issued by paginator having no tiebreaker
+	after := encodeCursor(s.T(), []string{"CreatedAt"}, order{CreatedAt: now.Add(2 * time.Hour)})
+
+	var orders []order
+	_, _, err := New(WithKeys("CreatedAt"), WithAfter(after)).Paginate(s.db, &orders)
+	s.Nil(err)
+	s.assertIDs(orders, 2, 1)
+}
+
+func (s *paginatorSuite) TestPaginateTiebreakerError() {
+	var orders []order
+	_, _, err := New(
+		WithKeys("CreatedAt"),
+		WithTiebreaker(TiebreakerError),
+	).Paginate(s.db, &orders)
+	s.Equal(ErrNonUniqueRules, err)
+
+	_, _, err = New(
+		WithKeys("CreatedAt", "ID"),
+		WithTiebreaker(TiebreakerError),
+	).Paginate(s.db, &orders)
+	s.Nil(err)
+}
+
+type uniqueIndexOrder struct {
+	ID        int       `gorm:"primaryKey"`
+	Remark    string    `gorm:"uniqueIndex:idx_remark_created_at"`
+	CreatedAt time.Time `gorm:"uniqueIndex:idx_remark_created_at"`
+}
+
+func (uniqueIndexOrder) TableName() string {
+	return "orders"
+}
+
+type uniqueFieldOrder struct {
+	ID     int     `gorm:"primaryKey"`
+	Remark *string `gorm:"unique"`
+}
+
+func (uniqueFieldOrder) TableName() string {
+	return "orders"
+}
+
+func (s *paginatorSuite) TestPaginateTiebreakerUniqueIndex() {
+	var orders []uniqueIndexOrder
+
+	// all fields of unique index are covered
+	_, _, err := New(
+		WithKeys("Remark", "CreatedAt"),
+		WithTiebreaker(TiebreakerError),
+	).Paginate(s.db, &orders)
+	s.Nil(err)
+
+	_, _, err = New(
+		WithKeys("Remark"),
+		WithTiebreaker(TiebreakerError),
+	).Paginate(s.db, &orders)
+	s.Equal(ErrNonUniqueRules, err)
+}
+
+func (s *paginatorSuite) TestPaginateTiebreakerNullableUniqueField() {
+	var orders []uniqueFieldOrder
+
+	// NULL values are not unique
+	_, _, err := New(
+		WithKeys("Remark"),
+		WithTiebreaker(TiebreakerError),
+	).Paginate(s.db, &orders)
+	s.Equal(ErrNonUniqueRules, err)
+}
+
+func (s *paginatorSuite) TestPaginateInvalidTiebreaker() {
+	var orders []order
+	_, _, err := New(
+		WithTiebreaker("SOMETIMES"),
+	).Paginate(s.db, &orders)
+	s.Equal(ErrInvalidTiebreaker, err)
+}
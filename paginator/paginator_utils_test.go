@@ -51,3 +51,67 @@ func TestIsNil(t *testing.T) {
 type testError struct{}
 
 func (*testError) Error() string { return "error" }
+
+func TestBuildOrderSQLEmulatesNullsOrder(t *testing.T) {
+	t.Parallel()
+
+	p := New(WithRules(
+		Rule{Key: "Remark", SQLRepr: "orders.remark", Order: ASC, NullsOrder: NullsLast},
+		Rule{Key: "ID", SQLRepr: "orders.id", Order: ASC},
+	))
+	query, _ := p.buildOrderSQL()
+	assert.Equal(
+		t,
+		"CASE WHEN orders.remark IS NULL THEN 1 ELSE 0 END ASC, orders.remark ASC, orders.id ASC",
+		query,
+	)
+
+	p.nativeNullsOrder = true
+	query, _ = p.buildOrderSQL()
+	assert.Equal(t, "orders.remark ASC NULLS LAST, orders.id ASC", query)
+
+	// both order and nulls order are flipped when paging backward
+	p.SetBeforeCursor("cursor")
+	query, _ = p.buildOrderSQL()
+	assert.Equal(t, "orders.remark DESC NULLS FIRST, orders.id DESC", query)
+}
+
+func TestBuildNullableCursorSQLQuery(t *testing.T) {
+	t.Parallel()
+
+	p := New(WithRules(
+		Rule{Key: "Remark", SQLRepr: "orders.remark", Order: ASC, NullsOrder: NullsLast},
+		Rule{Key: "ID", SQLRepr: "orders.id", Order: ASC},
+	))
+
+	query, args := p.buildKeysetSQLQuery([]interface{}{"r1", 1}, false)
+	assert.Equal(t, "(orders.remark > ? OR orders.remark IS NULL) OR orders.remark = ? AND orders.id > ?", query)
+	assert.Equal(t, []interface{}{"r1", "r1", 1}, args)
+
+	query, args = p.buildKeysetSQLQuery([]interface{}{nil, 1}, false)
+	assert.Equal(t, "orders.remark IS NULL AND orders.id > ?", query)
+	assert.Equal(t, []interface{}{1}, args)
+
+	query, args = p.buildKeysetSQLQuery([]interface{}{nil, 1}, true)
+	assert.Equal(t, "orders.remark IS NOT NULL OR orders.remark IS NULL AND orders.id < ?", query)
+	assert.Equal(t, []interface{}{1}, args)
+}
+
+func TestBuildGroupedCursorSQLQuery(t *testing.T) {
+	t.Parallel()
+
+	p := New(WithRules(
+		Rule{Key: "A", SQLRepr: "a", Order: ASC},
+		Rule{Key: "B", SQLRepr: "b", Order: ASC},
+		Rule{Key: "C", SQLRepr: "c", Order: DESC},
+	))
+
+	query, args := p.buildKeysetSQLQuery([]interface{}{1, 2, 3}, false)
+	assert.Equal(t, "a >= ? AND ((a > ? OR a = ? AND b > ?) OR a = ? AND b = ? AND c < ?)", query)
+	assert.Equal(t, []interface{}{1, 1, 1, 2, 1, 2, 3}, args)
+
+	p.tupleCmp = true
+	query, args = p.buildKeysetSQLQuery([]interface{}{1, 2, 3}, true)
+	assert.Equal(t, "a <= ? AND ((a, b) < ? OR (a, b) = ? AND c > ?)", query)
+	assert.Equal(t, []interface{}{1, []interface{}{1, 2}, []interface{}{1, 2}, 3}, args)
+}
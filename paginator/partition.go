@@ -0,0 +1,318 @@
+package paginator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PartitionStrategy decides how key range is split into partitions
+type PartitionStrategy string
+
+// Partition strategies
+const (
+	// RANGE splits [MIN, MAX] of numeric or time key into equal width ranges,
+	// it falls back to QUANTILE for other types of key.
+	RANGE PartitionStrategy = "RANGE"
+	// QUANTILE splits key range at quantiles, so that each partition has about the same number of rows.
+	QUANTILE PartitionStrategy = "QUANTILE"
+)
+
+// Partition is a contiguous range of the first rule's key. From is inclusive and To is
+// exclusive, nil means unbounded. The first partition also contains rows with NULL key.
+type Partition struct {
+	Index int
+	From  interface{}
+	To    interface{}
+}
+
+// PartitionHandler handles one batch of rows in partition, rows is a pointer to slice of dest type
+type PartitionHandler func(tx *gorm.DB, partition Partition, rows interface{}) error
+
+// PartitionError reports errors of failed partitions, keyed by partition index
+type PartitionError struct {
+	Errors map[int]error
+}
+
+func (e *PartitionError) Error() string {
+	indexes := make([]int, 0, len(e.Errors))
+	for i := range e.Errors {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	msgs := make([]string, len(indexes))
+	for i, index := range indexes {
+		msgs[i] = fmt.Sprintf("partition %d: %s", index, e.Errors[index])
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// NewParallelScanner creates parallel scanner, opts are applied to the paginator of every batch
+func NewParallelScanner(name string, store CheckpointStore, opts ...Option) *ParallelScanner {
+	return &ParallelScanner{
+		name:     name,
+		store:    store,
+		opts:     opts,
+		workers:  1,
+		strategy: RANGE,
+	}
+}
+
+// ParallelScanner splits key range of the first rule into partitions, and walks
+// through partitions concurrently, each by its own batch runner with its own checkpoint.
+type ParallelScanner struct {
+	name        string
+	store       CheckpointStore
+	opts        []Option
+	workers     int
+	strategy    PartitionStrategy
+	transaction bool
+	throttle    time.Duration
+}
+
+// SetWorkers sets number of partitions processed concurrently
+func (s *ParallelScanner) SetWorkers(workers int) {
+	s.workers = workers
+}
+
+// SetStrategy sets partition strategy used by Plan
+func (s *ParallelScanner) SetStrategy(strategy PartitionStrategy) {
+	s.strategy = strategy
+}
+
+// SetTransaction enables or disables wrapping each batch in its own transaction
+func (s *ParallelScanner) SetTransaction(enabled bool) {
+	s.transaction = enabled
+}
+
+// SetThrottle sets the duration to wait between batches of each partition
+func (s *ParallelScanner) SetThrottle(throttle time.Duration) {
+	s.throttle = throttle
+}
+
+// Plan splits key range of the first rule into at most n partitions. Plan should be kept
+// stable across retries, since partition checkpoints are only meaningful within their ranges.
+func (s *ParallelScanner) Plan(db *gorm.DB, dest interface{}, n int) ([]Partition, error) {
+	if n <= 0 {
+		return nil, ErrInvalidPartitions
+	}
+	rule, err := s.getRule(db, dest)
+	if err != nil {
+		return nil, err
+	}
+	stmt := db.Session(&gorm.Session{})
+	if stmt.Statement.Model == nil && stmt.Statement.Table == "" {
+		stmt = stmt.Model(dest)
+	}
+	var bounds []interface{}
+	if s.strategy == RANGE {
+		bounds, err = s.planRange(stmt, rule, n)
+	}
+	if s.strategy == QUANTILE || (err == nil && bounds == nil) {
+		bounds, err = s.planQuantile(stmt, rule, n)
+	}
+	if err != nil {
+		return nil, err
+	}
+	partitions := make([]Partition, 0, len(bounds)+1)
+	var from interface{}
+	for _, bound := range bounds {
+		partitions = append(partitions, Partition{Index: len(partitions), From: from, To: bound})
+		from = bound
+	}
+	return append(partitions, Partition{Index: len(partitions), From: from}), nil
+}
+
+// Run walks through given partitions concurrently, dest is a pointer to slice used as prototype
+// of rows passed to handler. Failed partitions are reported by *PartitionError, they can be
+// retried alone and resume from their own checkpoints.
+func (s *ParallelScanner) Run(db *gorm.DB, dest interface{}, partitions []Partition, handler PartitionHandler) error {
+	if !isSlicePtr(dest) {
+		return ErrInvalidDest
+	}
+	rule, err := s.getRule(db, dest)
+	if err != nil {
+		return err
+	}
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		errs   = make(map[int]error)
+		queue  = make(chan Partition)
+		worker = func() {
+			defer wg.Done()
+			for partition := range queue {
+				if err := s.runPartition(db, dest, rule, partition, handler); err != nil {
+					mu.Lock()
+					errs[partition.Index] = err
+					mu.Unlock()
+				}
+			}
+		}
+	)
+	workers := s.workers
+	if workers <= 0 {
+		workers = 1
+	}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go worker()
+	}
+	for _, partition := range partitions {
+		queue <- partition
+	}
+	close(queue)
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return &PartitionError{Errors: errs}
+	}
+	return nil
+}
+
+/* private */
+
+// getRule returns the first rule set up, whose key range is split into partitions
+func (s *ParallelScanner) getRule(db *gorm.DB, dest interface{}) (Rule, error) {
+	p := New(s.opts...)
+	if err := p.validate(db, dest); err != nil {
+		return Rule{}, err
+	}
+	if err := p.setup(db, dest); err != nil {
+		return Rule{}, err
+	}
+	return p.rules[0], nil
+}
+
+func (s *ParallelScanner) runPartition(
+	db *gorm.DB,
+	dest interface{},
+	rule Rule,
+	partition Partition,
+	handler PartitionHandler,
+) error {
+	runner := NewBatchRunner(fmt.Sprintf("%s/%d", s.name, partition.Index), s.store, s.opts...)
+	runner.SetTransaction(s.transaction)
+	runner.SetThrottle(s.throttle)
+
+	stmt := db.Session(&gorm.Session{})
+	if query, args := buildPartitionSQLQuery(rule, partition); query != "" {
+		stmt = stmt.Where(query, args...)
+	}
+	rows := reflect.New(reflect.TypeOf(dest).Elem()).Interface()
+	return runner.Run(stmt, rows, func(tx *gorm.DB, batch int) error {
+		return handler(tx, partition, rows)
+	})
+}
+
+func buildPartitionSQLQuery(rule Rule, partition Partition) (query string, args []interface{}) {
+	var queries []string
+	if partition.From != nil {
+		queries = append(queries, fmt.Sprintf("%s >= ?", rule.SQLRepr))
+		args = append(args, rule.bindVars(partition.From)...)
+	}
+	if partition.To != nil {
+		queries = append(queries, fmt.Sprintf("%s < ?", rule.SQLRepr))
+		args = append(args, rule.bindVars(partition.To)...)
+	}
+	query = strings.Join(queries, " AND ")
+	// rows with NULL key belong to the first partition
+	if partition.From == nil && partition.To != nil {
+		query = fmt.Sprintf("(%s OR %s IS NULL)", query, rule.SQLRepr)
+		args = append(args, rule.sqlVars...)
+	}
+	return
+}
+
+// planRange returns nil bounds when key is neither numeric nor time
+func (s *ParallelScanner) planRange(db *gorm.DB, rule Rule, n int) ([]interface{}, error) {
+	var min, max interface{}
+	stmt := db.Select(fmt.Sprintf("MIN(%s), MAX(%s)", rule.SQLRepr, rule.SQLRepr), rule.bindVars(rule.sqlVars...)...)
+	if err := stmt.Row().Scan(&min, &max); err != nil {
+		return nil, err
+	}
+	if min == nil || max == nil {
+		return []interface{}{}, nil
+	}
+	var bounds []interface{}
+	for i := 1; i < n; i++ {
+		var bound interface{}
+		switch minV := min.(type) {
+		case int64:
+			maxV, ok := max.(int64)
+			if !ok {
+				return nil, nil
+			}
+			bound = minV + int64(float64(maxV-minV)*float64(i)/float64(n))
+		case float64:
+			maxV, ok := max.(float64)
+			if !ok {
+				return nil, nil
+			}
+			bound = minV + (maxV-minV)*float64(i)/float64(n)
+		case time.Time:
+			maxV, ok := max.(time.Time)
+			if !ok {
+				return nil, nil
+			}
+			bound = minV.Add(time.Duration(float64(maxV.Sub(minV)) * float64(i) / float64(n)))
+		default:
+			return nil, nil
+		}
+		bounds = appendBound(bounds, bound, min)
+	}
+	return bounds, nil
+}
+
+func (s *ParallelScanner) planQuantile(db *gorm.DB, rule Rule, n int) ([]interface{}, error) {
+	stmt := db.Where(fmt.Sprintf("%s IS NOT NULL", rule.SQLRepr), rule.sqlVars...)
+	var count int64
+	// count all rows, rather than non-null values of narrow Select
+	if err := stmt.Session(&gorm.Session{}).Select([]string{}).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	var bounds []interface{}
+	var min interface{}
+	for i := 0; i < n; i++ {
+		offset := count * int64(i) / int64(n)
+		if offset >= count {
+			break
+		}
+		var bound interface{}
+		err := stmt.Session(&gorm.Session{}).
+			Select(rule.SQLRepr, rule.sqlVars...).
+			Clauses(clause.OrderBy{Expression: clause.Expr{SQL: rule.SQLRepr, Vars: rule.sqlVars}}).
+			Offset(int(offset)).
+			Limit(1).
+			Row().
+			Scan(&bound)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			// lower bound of the first partition is always unbounded
+			min = bound
+			continue
+		}
+		bounds = appendBound(bounds, bound, min)
+	}
+	return bounds, nil
+}
+
+// appendBound skips bounds equal to min or to the last bound, which would make empty partitions
+func appendBound(bounds []interface{}, bound interface{}, min interface{}) []interface{} {
+	last := min
+	if len(bounds) > 0 {
+		last = bounds[len(bounds)-1]
+	}
+	if reflect.DeepEqual(last, bound) {
+		return bounds
+	}
+	return append(bounds, bound)
+}
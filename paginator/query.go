@@ -0,0 +1,46 @@
+package paginator
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Query is the paging query built by BuildQuery, it is what Paginate runs for the same page
+type Query struct {
+	// DB is the composed query, calling Find(dest) on it runs the query
+	DB *gorm.DB
+	// SQL and Vars are the statement rendered by dry run, LIMIT is one more than paging limit
+	// so that Paginate can tell whether there are more rows
+	SQL  string
+	Vars []interface{}
+	// CursorValues are values decoded from cursor in the order of rules, after NULL replacement.
+	// It is nil when there is no cursor.
+	CursorValues []interface{}
+}
+
+// BuildQuery validates and decodes cursor as Paginate does, and returns the paging query
+// without executing it. The paginator itself is left untouched, so it can still paginate.
+// In sync and snapshot mode, building the first page of a session still queries its bound.
+// On SQLite, AUTO tuple comparison and nulls order query version of database once per *sql.DB,
+// or once per query in transaction.
+func (p *Paginator) BuildQuery(db *gorm.DB, dest interface{}) (*Query, error) {
+	stmt, fields, err := p.clone().buildPagingQuery(db, dest)
+	if err != nil {
+		return nil, err
+	}
+	dryRun := stmt.Session(&gorm.Session{DryRun: true}).Find(dest)
+	if dryRun.Error != nil {
+		// clauses added by scopes of db are only checked when query is built
+		if errors.Is(dryRun.Error, ErrClauseConflict) {
+			return nil, ErrClauseConflict
+		}
+		return nil, dryRun.Error
+	}
+	return &Query{
+		DB:           stmt.Session(&gorm.Session{}),
+		SQL:          dryRun.Statement.SQL.String(),
+		Vars:         dryRun.Statement.Vars,
+		CursorValues: fields,
+	}, nil
+}
@@ -1,10 +1,15 @@
 package paginator
 
 import (
-	"gorm.io/gorm"
+	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
 
-	"github.com/pilagod/gorm-cursor-paginator/v2/internal/util"
+	"github.com/pilagod/gorm-cursor-paginator/v2/cursor"
 )
 
 // Rule for paginator
@@ -12,9 +17,23 @@ type Rule struct {
 	Key             string
 	Order           Order
 	SQLRepr         string
+	SQLVars         []interface{}
 	SQLType         *string
 	NULLReplacement interface{}
+	NullsOrder      NullsOrder
 	CustomType      *CustomType
+	ValueType       reflect.Type
+
+	// sqlVars are bound parameters of placeholders in SQLRepr, set up along with SQLRepr
+	sqlVars []interface{}
+	// fieldPath is path of field on dest which holds value of key, set up along with SQLRepr
+	fieldPath string
+	// dbName is column of key, and column is its qualified form when SQLRepr is generated
+	// from it, they are used to check columns selected by query
+	dbName string
+	column string
+	// rawSQLRepr is SQLRepr before wrapped by NULLReplacement and SQLType
+	rawSQLRepr string
 }
 
 // CustomType for paginator. It provides extra info needed to paginate across custom types (e.g. JSON)
@@ -24,15 +43,170 @@ type CustomType struct {
 }
 
 func (r *Rule) validate(db *gorm.DB, dest interface{}) (err error) {
-	if schema, err := util.ParseSchema(db, dest); err != nil {
-		return ErrInvalidModel
-	} else if f := schema.LookUpField(r.Key); f == nil {
-		return ErrInvalidModel
+	if _, _, err = resolveKey(db, dest, r.Key); err != nil {
+		return
 	}
 	if r.Order != "" {
 		if err = r.Order.validate(); err != nil {
 			return
 		}
 	}
+	if r.NullsOrder != "" {
+		if err = r.NullsOrder.validate(); err != nil {
+			return
+		}
+	}
+	if r.SQLType != nil && !isAllowedSQLType(*r.SQLType) {
+		return ErrInvalidSQLType
+	}
+	if len(r.SQLVars) > 0 && strings.Count(r.SQLRepr, "?") != len(r.SQLVars) {
+		return ErrInvalidSQLVars
+	}
 	return nil
 }
+
+// reset restores rule set up by previous pagination to the one given, so that reused
+// paginator sets up rules from scratch instead of wrapping SQL representations again
+func (r *Rule) reset() {
+	if r.rawSQLRepr == "" {
+		return
+	}
+	// SQL representation generated from column of key is generated again
+	if r.column == "" {
+		r.SQLRepr = r.rawSQLRepr
+	} else {
+		r.SQLRepr = ""
+	}
+	r.sqlVars, r.fieldPath, r.dbName, r.column, r.rawSQLRepr = nil, "", "", "", ""
+}
+
+// wrapSQLRepr wraps SQL representation by NULL replacement and type casting of rule,
+// NULL replacement is passed as bound parameter
+func (r *Rule) wrapSQLRepr(sqlRepr string) (string, []interface{}) {
+	if r.NULLReplacement == nil {
+		return r.castSQLRepr(sqlRepr, ""), nil
+	}
+	return r.castSQLRepr(sqlRepr, "?"), []interface{}{r.NULLReplacement}
+}
+
+// wrapSQLReprLiteral wraps SQL representation as wrapSQLRepr does, but with NULL replacement
+// inlined as string literal, for statements not taking bound parameters (e.g. index DDL)
+func (r *Rule) wrapSQLReprLiteral(sqlRepr string) string {
+	if r.NULLReplacement == nil {
+		return r.castSQLRepr(sqlRepr, "")
+	}
+	return r.castSQLRepr(sqlRepr, quoteLiteral(r.NULLReplacement))
+}
+
+func (r *Rule) castSQLRepr(sqlRepr string, nullReplacement string) string {
+	if nullReplacement != "" {
+		if r.SQLType != nil {
+			nullReplacement = fmt.Sprintf("CAST(%s as %s)", nullReplacement, *r.SQLType)
+		}
+		sqlRepr = fmt.Sprintf("COALESCE(%s, %s)", sqlRepr, nullReplacement)
+	}
+	// cast to the underlying SQL type
+	if r.SQLType != nil {
+		sqlRepr = fmt.Sprintf("CAST( %s AS %s )", sqlRepr, *r.SQLType)
+	}
+	return sqlRepr
+}
+
+// bindVars returns vars of SQL representation followed by args, in the order of placeholders
+// of query like "<SQLRepr> > ?"
+func (r *Rule) bindVars(args ...interface{}) []interface{} {
+	return append(append(make([]interface{}, 0, len(r.sqlVars)+len(args)), r.sqlVars...), args...)
+}
+
+// getFieldPath returns path of field which cursor reads value of rule by
+func (r *Rule) getFieldPath() string {
+	if r.fieldPath != "" {
+		return r.fieldPath
+	}
+	return r.Key
+}
+
+func (r *Rule) getEncoderField() cursor.EncoderField {
+	field := cursor.EncoderField{Key: r.getFieldPath()}
+	if r.CustomType != nil {
+		field.Meta = r.CustomType.Meta
+	}
+	return field
+}
+
+func (r *Rule) getDecoderField() cursor.DecoderField {
+	field := cursor.DecoderField{Key: r.getFieldPath()}
+	if r.CustomType != nil {
+		field.Type = &r.CustomType.Type
+	} else if r.ValueType != nil {
+		// rule may be a loop variable, which is shared between iterations
+		t := r.ValueType
+		field.Type = &t
+	}
+	return field
+}
+
+/* SQL types */
+
+var (
+	sqlTypesMu sync.RWMutex
+	// sqlTypes are type names allowed in SQLType, across dialects
+	sqlTypes = map[string]bool{
+		"bigint": true, "binary": true, "bit": true, "blob": true, "bool": true, "boolean": true,
+		"bytea": true, "char": true, "character": true, "character varying": true, "date": true,
+		"datetime": true, "datetime2": true, "datetimeoffset": true, "decimal": true, "double": true,
+		"double precision": true, "float": true, "int": true, "integer": true, "interval": true,
+		"json": true, "jsonb": true, "mediumint": true, "nchar": true, "numeric": true, "nvarchar": true,
+		"real": true, "signed": true, "signed integer": true, "smallint": true, "text": true, "time": true,
+		"timestamp": true, "timestamp with time zone": true, "timestamp without time zone": true,
+		"timestamptz": true, "tinyint": true, "unsigned": true, "unsigned integer": true, "uuid": true,
+		"varbinary": true, "varchar": true,
+	}
+	// sqlTypePattern matches type name with optional length or precision, e.g. numeric(10, 2)
+	sqlTypePattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_ ]*?)\s*(\(\s*\d+\s*(,\s*\d+\s*)?\))?$`)
+)
+
+// AllowSQLTypes adds type names allowed in SQLType of rules, e.g. custom types of database.
+// SQLType is spliced into SQL as it is, so only trusted type names should be allowed.
+func AllowSQLTypes(types ...string) {
+	sqlTypesMu.Lock()
+	defer sqlTypesMu.Unlock()
+	for _, t := range types {
+		sqlTypes[normalizeSQLTypeName(t)] = true
+	}
+}
+
+func isAllowedSQLType(t string) bool {
+	m := sqlTypePattern.FindStringSubmatch(strings.TrimSpace(t))
+	if m == nil {
+		return false
+	}
+	sqlTypesMu.RLock()
+	defer sqlTypesMu.RUnlock()
+	return sqlTypes[normalizeSQLTypeName(m[1])]
+}
+
+func normalizeSQLTypeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// inlineSQLVars replaces placeholders in sql by vars quoted as string literals. Placeholders
+// are scanned on sql only, so that placeholders in inlined vars are left as they are.
+func inlineSQLVars(sql string, vars []interface{}) string {
+	var b strings.Builder
+	i := 0
+	for _, r := range sql {
+		if r == '?' && i < len(vars) {
+			b.WriteString(quoteLiteral(vars[i]))
+			i++
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// quoteLiteral quotes v as SQL string literal
+func quoteLiteral(v interface{}) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(fmt.Sprint(v), "'", "''"))
+}
@@ -0,0 +1,70 @@
+package paginator
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// QueryStrategy type for the way of seeking rows after cursor
+type QueryStrategy string
+
+// QueryStrategies
+const (
+	// QueryStrategyDefault seeks rows by a single query with OR predicates
+	QueryStrategyDefault QueryStrategy = "DEFAULT"
+	// QueryStrategyUnionAll seeks rows by UNION ALL of range queries, one for each prefix of rules,
+	// for planners not using index on OR predicates (e.g. MySQL)
+	QueryStrategyUnionAll QueryStrategy = "UNION_ALL"
+)
+
+func (s *QueryStrategy) validate() error {
+	if *s != QueryStrategyDefault && *s != QueryStrategyUnionAll {
+		return ErrInvalidQueryStrategy
+	}
+	return nil
+}
+
+// appendUnionPagingQuery seeks rows after fields by UNION ALL of range queries, each of which
+// has its own ORDER BY and LIMIT, and then orders and limits them again as a whole:
+//
+//	SELECT * FROM (
+//	  SELECT * FROM (SELECT * FROM orders WHERE a > 1 ORDER BY a, b LIMIT 11) AS seek_0
+//	  UNION ALL
+//	  SELECT * FROM (SELECT * FROM orders WHERE a = 1 AND b > 2 ORDER BY a, b LIMIT 11) AS seek_1
+//	) AS orders ORDER BY a, b LIMIT 11
+func (p *Paginator) appendUnionPagingQuery(db *gorm.DB, dest interface{}, fields []interface{}, limit int) (*gorm.DB, error) {
+	table, err := p.getTableName(db, dest)
+	if err != nil {
+		return nil, err
+	}
+	queries, args := p.buildCursorSQLQueryBranches(fields, p.isBackward())
+	if len(queries) == 0 {
+		return p.appendPagingQuery(db.Where("1 = 0"), nil, limit), nil
+	}
+	base := db
+	if base.Statement.Model == nil && base.Statement.Table == "" {
+		base = base.Model(dest)
+	}
+	branches := make([]string, len(queries))
+	vars := make([]interface{}, 0, len(queries)+1)
+	for i, query := range queries {
+		branches[i] = fmt.Sprintf("SELECT * FROM (?) AS seek_%d", i)
+		branch := base.Session(&gorm.Session{}).Scopes(withCursorQuery(clause.Expr{SQL: query, Vars: args[i]}))
+		vars = append(vars, p.appendPagingQuery(branch, nil, limit))
+	}
+	// alias union as model table, so that SQL representations of rules can be used as they are
+	vars = append(vars, clause.Table{Name: table})
+	orderSQL, orderVars := p.buildOrderSQL()
+	return db.Session(&gorm.Session{NewDB: true}).Raw(
+		fmt.Sprintf(
+			"SELECT * FROM (%s) AS ? ORDER BY %s LIMIT %d",
+			strings.Join(branches, " UNION ALL "),
+			orderSQL,
+			limit,
+		),
+		append(vars, orderVars...)...,
+	), nil
+}
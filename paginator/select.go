@@ -0,0 +1,138 @@
+package paginator
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// selectRuleColumns makes sure narrow Select of query selects columns of rules, otherwise fields
+// of rules are left zero in dest, and cursor is silently built from zero values. Columns of rules
+// are added to selection of a new statement, while rules on expressions need their aliases
+// selected by query. Aliases of keys selecting other expressions than rules are rejected.
+func (p *Paginator) selectRuleColumns(stmt *gorm.DB) (*gorm.DB, error) {
+	items, ok := selectedItems(stmt.Statement)
+	if !ok {
+		return stmt, nil
+	}
+	var columns []string
+	for _, rule := range p.rules {
+		// columns of joined tables are selected by Joins
+		if rule.dbName == "" {
+			continue
+		}
+		if expr, ok := items[strings.ToLower(rule.dbName)]; ok {
+			if !selectsRule(expr, rule) {
+				return nil, fmt.Errorf("%w: %s", ErrMissingSelectColumn, rule.Key)
+			}
+			continue
+		}
+		if rule.column == "" {
+			return nil, fmt.Errorf("%w: %s", ErrMissingSelectColumn, rule.Key)
+		}
+		columns = append(columns, rule.column)
+	}
+	if len(columns) == 0 {
+		return stmt, nil
+	}
+	if c, ok := stmt.Statement.Clauses["SELECT"]; ok {
+		if expr, ok := c.Expression.(clause.Expr); ok {
+			return stmt.Clauses(clause.Select{
+				Distinct:   stmt.Statement.Distinct,
+				Expression: clause.Expr{SQL: expr.SQL + ", " + strings.Join(columns, ", "), Vars: expr.Vars},
+			}), nil
+		}
+	}
+	return stmt.Select(append(append([]string{}, stmt.Statement.Selects...), columns...)), nil
+}
+
+// selectedItems returns expressions selected by narrow Select of stmt, keyed by lowercased names
+// of their columns or aliases. It returns false when all columns are selected, or the selection
+// cannot be told.
+func selectedItems(stmt *gorm.Statement) (map[string]string, bool) {
+	var items []string
+	if c, ok := stmt.Clauses["SELECT"]; ok && c.Expression != nil {
+		expr, ok := c.Expression.(clause.Expr)
+		if !ok {
+			return nil, false
+		}
+		items = splitSelectItems(expr.SQL)
+	} else {
+		for _, s := range stmt.Selects {
+			items = append(items, splitSelectItems(s)...)
+		}
+	}
+	if len(items) == 0 {
+		return nil, false
+	}
+	exprs := make(map[string]string, len(items))
+	for _, item := range items {
+		expr, name := splitSelectItem(item)
+		if name == "*" {
+			return nil, false
+		}
+		exprs[name] = expr
+	}
+	return exprs, true
+}
+
+// selectsRule tells whether expr selected as column of rule is the column or SQL representation of rule
+func selectsRule(expr string, rule Rule) bool {
+	if isColumnRef(expr) && columnName(expr) == strings.ToLower(rule.dbName) {
+		return true
+	}
+	return normalizeSQL(expr) == normalizeSQL(rule.rawSQLRepr)
+}
+
+// isColumnRef tells whether expr is a plain column, optionally qualified by table
+func isColumnRef(expr string) bool {
+	return expr != "" && !strings.ContainsAny(expr, "()?'+-*/% \t")
+}
+
+func columnName(ref string) string {
+	if i := strings.LastIndex(ref, "."); i >= 0 {
+		ref = ref[i+1:]
+	}
+	return strings.Trim(ref, "\"`[]")
+}
+
+// splitSelectItems splits selection by commas outside of parentheses
+func splitSelectItems(sql string) (items []string) {
+	depth, start := 0, 0
+	for i, r := range sql {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				items = append(items, sql[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(items, sql[start:])
+}
+
+// splitSelectItem returns lowercased expression and name of column or alias selected by item,
+// e.g. "created_at" for `"orders"."created_at"`, and "distance" for "ABS(orders.id - ?) AS distance"
+func splitSelectItem(item string) (expr string, name string) {
+	expr = strings.ToLower(strings.TrimSpace(item))
+	// DISTINCT applies to the whole selection, rather than to its first item
+	if fields := strings.Fields(expr); len(fields) > 1 && fields[0] == "distinct" {
+		expr = strings.TrimSpace(expr[len("distinct"):])
+	}
+	name = expr
+	if i := strings.LastIndex(expr, " as "); i >= 0 {
+		expr, name = expr[:i], expr[i+len(" as "):]
+	} else if i := strings.LastIndexAny(expr, " \t\n"); i >= 0 {
+		// alias without AS only follows a complete expression, e.g. column or function call
+		if rest := strings.TrimSpace(expr[:i]); isColumnRef(rest) || strings.HasSuffix(rest, ")") {
+			expr, name = rest, expr[i+1:]
+		}
+	}
+	return strings.TrimSpace(expr), columnName(strings.TrimSpace(name))
+}
@@ -0,0 +1,153 @@
+package paginator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	pc "github.com/pilagod/gorm-cursor-paginator/v2/cursor"
+	"github.com/pilagod/gorm-cursor-paginator/v2/internal/util"
+)
+
+// sessionCursor wraps paging cursor with states of the session it belongs to.
+// Sessions start at the first page without cursor, and states captured there
+// are carried through cursors of all later pages.
+type sessionCursor struct {
+	Cursor string `json:"c"`
+	// sync mode
+	Bound *string `json:"b,omitempty"`
+	Since *string `json:"s,omitempty"`
+	// snapshot mode
+	Snapshot *string `json:"n,omitempty"`
+}
+
+func encodeSessionCursor(sc sessionCursor) (string, error) {
+	b, err := json.Marshal(sc)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func decodeSessionCursor(c string) (sc sessionCursor, err error) {
+	b, err := base64.StdEncoding.DecodeString(c)
+	if err != nil {
+		return sc, ErrInvalidCursor
+	}
+	if err = json.Unmarshal(b, &sc); err != nil {
+		return sc, ErrInvalidCursor
+	}
+	return
+}
+
+func (p *Paginator) isSession() bool {
+	return p.sync.enabled || p.snapshot.enabled()
+}
+
+func (p *Paginator) setupSession(db *gorm.DB, dest interface{}) (err error) {
+	var c **string
+	if p.isForward() {
+		c = &p.cursor.After
+	} else if p.isBackward() {
+		c = &p.cursor.Before
+	}
+	// nil session cursor means a new session
+	var sc *sessionCursor
+	if c != nil {
+		decoded, err := decodeSessionCursor(**c)
+		if err != nil {
+			return err
+		}
+		sc = &decoded
+		*c = &sc.Cursor
+	}
+	if p.sync.enabled {
+		if err = p.setupSync(db, dest, sc); err != nil {
+			return
+		}
+	}
+	if p.snapshot.enabled() {
+		if err = p.setupSnapshot(db, dest, sc); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (p *Paginator) appendSessionQuery(stmt *gorm.DB) *gorm.DB {
+	if p.sync.enabled {
+		stmt = p.appendSyncQuery(stmt)
+	}
+	if p.snapshot.enabled() {
+		stmt = p.appendSnapshotQuery(stmt)
+	}
+	return stmt
+}
+
+func (p *Paginator) encodeSessionCursor(c Cursor, elems reflect.Value) (result Cursor, err error) {
+	wrap := func(inner *string) (*string, error) {
+		if inner == nil {
+			return nil, nil
+		}
+		sc, err := encodeSessionCursor(sessionCursor{
+			Cursor:   *inner,
+			Bound:    p.sync.bound,
+			Since:    p.sync.token,
+			Snapshot: p.snapshot.bound,
+		})
+		return &sc, err
+	}
+	if result.After, err = wrap(c.After); err != nil {
+		return
+	}
+	if result.Before, err = wrap(c.Before); err != nil {
+		return
+	}
+	if p.sync.enabled {
+		err = p.encodeSyncToken(c, elems)
+	}
+	return
+}
+
+// captureBound encodes max value of rule among rows matched by db, it returns nil when no rows matched
+func (p *Paginator) captureBound(db *gorm.DB, dest interface{}, rule Rule) (*string, error) {
+	elems := reflect.New(reflect.SliceOf(util.ReflectType(dest)))
+	err := db.Session(&gorm.Session{}).
+		Where(fmt.Sprintf("%s IS NOT NULL", rule.SQLRepr), rule.sqlVars...).
+		Scopes(p.withClauses(
+			clause.OrderBy{Expression: clause.Expr{SQL: fmt.Sprintf("%s DESC", rule.SQLRepr), Vars: rule.sqlVars}},
+			clause.Limit{Limit: 1},
+		)).
+		Find(elems.Interface()).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if elems.Elem().Len() == 0 {
+		return nil, nil
+	}
+	bound, err := p.cursorCodec.Encode(
+		[]pc.EncoderField{rule.getEncoderField()},
+		elems.Elem().Index(0),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &bound, nil
+}
+
+func (p *Paginator) decodeBound(bound string, dest interface{}, rule Rule) (interface{}, error) {
+	fields, err := p.cursorCodec.Decode(
+		[]pc.DecoderField{rule.getDecoderField()},
+		bound,
+		dest,
+	)
+	if err != nil || len(fields) != 1 {
+		return nil, ErrInvalidCursor
+	}
+	return fields[0], nil
+}
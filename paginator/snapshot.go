@@ -0,0 +1,64 @@
+package paginator
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// snapshotState holds states of snapshot mode. In snapshot mode, the first page of a
+// session captures max value of snapshot key as bound, and pages of the session exclude
+// rows beyond the bound, so that rows created during the session do not shift pages.
+type snapshotState struct {
+	rule Rule
+	// bound is the encoded bound, nil means there were no rows when the session started
+	bound *string
+	// boundValue is the decoded bound
+	boundValue interface{}
+	// newRows is the number of rows beyond the bound
+	newRows int64
+}
+
+func (s *snapshotState) enabled() bool {
+	return s.rule.Key != ""
+}
+
+func (p *Paginator) setupSnapshot(db *gorm.DB, dest interface{}, sc *sessionCursor) (err error) {
+	rule := &p.snapshot.rule
+	if err = p.setupRule(db, dest, rule); err != nil {
+		return
+	}
+	if sc == nil {
+		p.snapshot.bound, err = p.captureBound(db, dest, *rule)
+	} else {
+		p.snapshot.bound = sc.Snapshot
+	}
+	if err != nil || p.snapshot.bound == nil {
+		return
+	}
+	if p.snapshot.boundValue, err = p.decodeBound(*p.snapshot.bound, dest, *rule); err != nil {
+		return
+	}
+	// count rows beyond the bound, there are no new rows at the first page
+	if sc != nil {
+		// count all rows, rather than non-null values of narrow Select
+		stmt := db.Session(&gorm.Session{}).Select([]string{})
+		if stmt.Statement.Model == nil && stmt.Statement.Table == "" {
+			stmt = stmt.Model(dest)
+		}
+		err = stmt.
+			Where(fmt.Sprintf("%s > ?", rule.SQLRepr), rule.bindVars(p.snapshot.boundValue)...).
+			Count(&p.snapshot.newRows).
+			Error
+	}
+	return
+}
+
+func (p *Paginator) appendSnapshotQuery(stmt *gorm.DB) *gorm.DB {
+	if p.snapshot.bound == nil {
+		// there were no rows when the session started
+		return stmt.Where("1 = 0")
+	}
+	rule := p.snapshot.rule
+	return stmt.Where(fmt.Sprintf("%s <= ?", rule.SQLRepr), rule.bindVars(p.snapshot.boundValue)...)
+}
@@ -0,0 +1,79 @@
+package paginator
+
+import (
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+)
+
+// syncState holds states of sync mode. In sync mode, the first page of a session captures
+// max value of the first rule as high-water mark, and pages of the session only contain
+// rows coming after sync token and not beyond high-water mark. Rows updated during the
+// session go beyond high-water mark, they are left to next session instead of being
+// skipped or shown twice.
+type syncState struct {
+	enabled bool
+	// token is the sync token from last session, nil means syncing from the beginning
+	token *string
+	// nextToken is the sync token for next session, it is set on the final page
+	nextToken *string
+	// bound is the encoded high-water mark, nil means there is nothing to sync
+	bound *string
+	// boundValue is the decoded high-water mark
+	boundValue interface{}
+	// sinceFields are the decoded fields of token
+	sinceFields []interface{}
+}
+
+func (p *Paginator) setupSync(db *gorm.DB, dest interface{}, sc *sessionCursor) (err error) {
+	if p.rules[0].Order != ASC {
+		return ErrInvalidSyncOrder
+	}
+	if sc != nil {
+		p.sync.bound = sc.Bound
+		p.sync.token = sc.Since
+	} else if p.sync.bound, err = p.captureBound(db, dest, p.rules[0]); err != nil {
+		return
+	}
+	if p.sync.bound != nil {
+		if p.sync.boundValue, err = p.decodeBound(*p.sync.bound, dest, p.rules[0]); err != nil {
+			return
+		}
+	}
+	if p.sync.token != nil {
+		if p.sync.sinceFields, err = p.decodeFields(*p.sync.token, dest); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (p *Paginator) appendSyncQuery(stmt *gorm.DB) *gorm.DB {
+	if p.sync.bound == nil {
+		// there was nothing to sync when the session started
+		return stmt.Where("1 = 0")
+	}
+	stmt = stmt.Where(fmt.Sprintf("%s <= ?", p.rules[0].SQLRepr), p.rules[0].bindVars(p.sync.boundValue)...)
+	if p.sync.sinceFields != nil {
+		query, args := p.buildKeysetSQLQuery(p.sync.sinceFields, false)
+		stmt = stmt.Where(query, args...)
+	}
+	return stmt
+}
+
+// encodeSyncToken sets sync token for next session when reaching the final page of the session
+func (p *Paginator) encodeSyncToken(c Cursor, elems reflect.Value) error {
+	if p.isBackward() || c.After != nil {
+		return nil
+	}
+	p.sync.nextToken = p.sync.token
+	if elems.Kind() == reflect.Slice && elems.Len() > 0 {
+		token, err := p.cursorCodec.Encode(p.getEncoderFields(), elems.Index(elems.Len()-1))
+		if err != nil {
+			return err
+		}
+		p.sync.nextToken = &token
+	}
+	return nil
+}
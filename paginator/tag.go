@@ -0,0 +1,106 @@
+package paginator
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// TagName is the struct tag declaring default paging rules of model, for example:
+//
+//	CreatedAt time.Time `paginate:"order=desc,priority=1"`
+//	ID        int       `paginate:"priority=2"`
+//
+// Settings are all optional: order (asc, desc), nulls (first, last) and priority,
+// fields with lower priority come first, default priority is 10.
+const TagName = "paginate"
+
+const defaultTagPriority = 10
+
+// setDefaultRules sets rules declared by paginate tags of model, or rules
+// on primary key fields of model in declaration order when there is no tag.
+func (p *Paginator) setDefaultRules(db *gorm.DB, dest interface{}) error {
+	s, err := parseModelSchema(db, dest)
+	if err != nil {
+		return ErrInvalidModel
+	}
+	rules, err := parseTagRules(s)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		for _, field := range s.PrimaryFields {
+			rules = append(rules, Rule{Key: keyOf(s, field)})
+		}
+	}
+	p.SetRules(rules...)
+	return nil
+}
+
+func parseTagRules(s *schema.Schema) ([]Rule, error) {
+	var rules []Rule
+	var priorities []int
+	for _, field := range s.Fields {
+		tag, ok := field.Tag.Lookup(TagName)
+		if !ok {
+			continue
+		}
+		rule, priority, err := parseTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		rule.Key = keyOf(s, field)
+		rules = append(rules, rule)
+		priorities = append(priorities, priority)
+	}
+	// sort rules and priorities together, keeping declaration order on same priority
+	indexes := make([]int, len(rules))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return priorities[indexes[i]] < priorities[indexes[j]]
+	})
+	sorted := make([]Rule, len(rules))
+	for i, index := range indexes {
+		sorted[i] = rules[index]
+	}
+	return sorted, nil
+}
+
+func parseTag(tag string) (rule Rule, priority int, err error) {
+	priority = defaultTagPriority
+	for _, setting := range strings.Split(tag, ",") {
+		setting = strings.TrimSpace(setting)
+		if setting == "" {
+			continue
+		}
+		kv := strings.SplitN(setting, "=", 2)
+		if len(kv) != 2 {
+			return Rule{}, 0, ErrInvalidTag
+		}
+		key, value := strings.ToLower(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1])
+		switch key {
+		case "order":
+			rule.Order = Order(strings.ToUpper(value))
+			if err = rule.Order.validate(); err != nil {
+				return Rule{}, 0, ErrInvalidTag
+			}
+		case "nulls":
+			rule.NullsOrder = NullsOrder("NULLS " + strings.ToUpper(value))
+			if err = rule.NullsOrder.validate(); err != nil {
+				return Rule{}, 0, ErrInvalidTag
+			}
+		case "priority":
+			if priority, err = strconv.Atoi(value); err != nil {
+				return Rule{}, 0, ErrInvalidTag
+			}
+		default:
+			return Rule{}, 0, ErrInvalidTag
+		}
+	}
+	return
+}
@@ -0,0 +1,118 @@
+package paginator
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Tiebreaker type for handling rules not known to be unique
+type Tiebreaker string
+
+// Tiebreakers
+const (
+	// TiebreakerAppend appends primary key fields as final rules, if model has any
+	TiebreakerAppend Tiebreaker = "APPEND"
+	// TiebreakerError fails paginating with ErrNonUniqueRules
+	TiebreakerError Tiebreaker = "ERROR"
+	// TiebreakerNone leaves rules as they are
+	TiebreakerNone Tiebreaker = "NONE"
+)
+
+func (t *Tiebreaker) validate() error {
+	if *t != TiebreakerAppend && *t != TiebreakerError && *t != TiebreakerNone {
+		return ErrInvalidTiebreaker
+	}
+	return nil
+}
+
+// setupTiebreaker makes sure rules identify a row uniquely, otherwise rows having
+// equal values on all rules may be skipped or duplicated between pages. Rules are
+// known to be unique when they cover all primary key fields, a non-nullable unique
+// field, or all fields of a unique index.
+func (p *Paginator) setupTiebreaker(db *gorm.DB, dest interface{}) error {
+	// primary key of table-only query is unknown, and rows of grouped query are groups,
+	// which are identified by grouping keys rather than primary key
+	if p.tiebreaker == TiebreakerNone || isMapDest(dest) && db.Statement.Model == nil || isGroupedQuery(db.Statement) {
+		return nil
+	}
+	s, err := parseModelSchema(db, dest)
+	if err != nil {
+		return err
+	}
+	if isUniqueRules(s, p.rules) {
+		return nil
+	}
+	if p.tiebreaker == TiebreakerError {
+		return ErrNonUniqueRules
+	}
+	order := p.rules[len(p.rules)-1].Order
+	for _, field := range s.PrimaryFields {
+		if hasRuleOn(s, p.rules, field) {
+			continue
+		}
+		rule := Rule{Key: keyOf(s, field), Order: order}
+		if err := p.setupRule(db, dest, &rule); err != nil {
+			return err
+		}
+		p.rules = append(p.rules, rule)
+	}
+	return nil
+}
+
+func isUniqueRules(s *schema.Schema, rules []Rule) bool {
+	if len(s.PrimaryFields) > 0 && coversFields(s, rules, s.PrimaryFields) {
+		return true
+	}
+	for _, field := range s.Fields {
+		if field.Unique && !isNullableField(field) && hasRuleOn(s, rules, field) {
+			return true
+		}
+	}
+	for _, index := range s.ParseIndexes() {
+		if index.Class != "UNIQUE" {
+			continue
+		}
+		fields := make([]*schema.Field, 0, len(index.Fields))
+		for _, opt := range index.Fields {
+			if opt.Expression != "" || isNullableField(opt.Field) {
+				fields = nil
+				break
+			}
+			fields = append(fields, opt.Field)
+		}
+		if len(fields) > 0 && coversFields(s, rules, fields) {
+			return true
+		}
+	}
+	return false
+}
+
+func coversFields(s *schema.Schema, rules []Rule, fields []*schema.Field) bool {
+	for _, field := range fields {
+		if !hasRuleOn(s, rules, field) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasRuleOn tells whether there is a rule paginating by given field itself,
+// rules across custom types only paginate by part of the field.
+func hasRuleOn(s *schema.Schema, rules []Rule, field *schema.Field) bool {
+	for _, rule := range rules {
+		if rule.CustomType != nil {
+			continue
+		}
+		if key, ok := lookUpKey(s, rule.Key); ok && len(key.relations) == 0 && key.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// isNullableField tells whether field may hold NULL values, which are never equal to each other
+func isNullableField(field *schema.Field) bool {
+	return field.FieldType.Kind() == reflect.Ptr
+}
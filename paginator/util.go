@@ -1,6 +1,11 @@
 package paginator
 
-import "reflect"
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
 
 func reverse(elems reflect.Value) reflect.Value {
 	result := reflect.MakeSlice(elems.Type(), 0, elems.Cap())
@@ -9,3 +14,14 @@ func reverse(elems reflect.Value) reflect.Value {
 	}
 	return result
 }
+
+// isSlicePtr tells whether dest is a pointer to slice, which rows can be scanned into
+func isSlicePtr(dest interface{}) bool {
+	t := reflect.TypeOf(dest)
+	return t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Slice
+}
+
+// quoteColumn quotes table and column names in the way of dialect of db
+func quoteColumn(db *gorm.DB, table string, column string) string {
+	return db.Statement.Quote(clause.Column{Table: table, Name: column})
+}